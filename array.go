@@ -1,6 +1,7 @@
 package pretty
 
 import (
+	"math"
 	"reflect"
 	"strconv"
 )
@@ -14,16 +15,28 @@ type ArrayWriter struct {
 	// Default: false.
 	ShowIndexes bool
 	// MaxLen is the maximum length of the array.
+	// If the array exceeds this length, it is truncated (see Truncate).
 	// Default: 0 (no limit).
 	MaxLen int
+	// Truncate is the mode used to truncate the array.
+	// Default: [TruncateModeHead].
+	Truncate TruncateMode
+	// CollapseRepeated collapses the consecutive equal elements.
+	// The first element is written, followed by `<repeated N times>` for the N next elements.
+	// The elements are compared before they are written, as with the == operator, and the pointers are compared by their target.
+	// The elements that are not comparable (e.g. slices, maps) are never collapsed.
+	// Default: false.
+	CollapseRepeated bool
 }
 
 // NewArrayWriter creates a new [ArrayWriter] with default values.
 func NewArrayWriter(vw ValueWriter) *ArrayWriter {
 	return &ArrayWriter{
-		ValueWriter: vw,
-		ShowIndexes: false,
-		MaxLen:      0,
+		ValueWriter:      vw,
+		ShowIndexes:      false,
+		MaxLen:           0,
+		Truncate:         TruncateModeHead,
+		CollapseRepeated: false,
 	}
 }
 
@@ -32,7 +45,7 @@ func (vw *ArrayWriter) WriteValue(st *State, v reflect.Value) bool {
 	if v.Kind() != reflect.Array {
 		return false
 	}
-	writeArray(st, v, vw.ShowIndexes, vw.MaxLen, vw.ValueWriter)
+	writeArray(st, v, vw.ShowIndexes, vw.MaxLen, vw.Truncate, vw.CollapseRepeated, vw.ValueWriter)
 	return true
 }
 
@@ -45,33 +58,128 @@ func (vw *ArrayWriter) Supports(typ reflect.Type) ValueWriter {
 	return res
 }
 
-func writeArray(st *State, v reflect.Value, showIndexes bool, maxLen int, vw ValueWriter) {
+func writeArray(st *State, v reflect.Value, showIndexes bool, maxLen int, truncate TruncateMode, collapseRepeated bool, vw ValueWriter) {
 	l := v.Len()
-	truncated := false
-	if maxLen > 0 && l > maxLen {
-		l = maxLen
-		truncated = true
-	}
+	head, tail, omitted := getTruncateLens(l, maxLen, truncate)
 	st.Writer.AppendByte('{')
 	if l > 0 {
-		st.Writer.AppendByte('\n')
 		st.IndentLevel++
-		for i := range l {
-			st.WriteIndent()
-			if showIndexes {
-				st.Writer = strconv.AppendInt(st.Writer, int64(i), 10)
-				st.Writer.AppendString(": ")
-			}
-			vw.WriteValue(st, v.Index(i))
-			st.Writer.AppendString(",\n")
-		}
-		if truncated {
-			st.WriteIndent()
-			writeTruncated(st)
-			st.Writer.AppendByte('\n')
+		writeArrayItems(st, v, 0, head, showIndexes, collapseRepeated, vw)
+		if omitted > 0 {
+			writeBlockOmitted(st, head == 0, omitted)
 		}
+		writeArrayItems(st, v, l-tail, l, showIndexes, collapseRepeated, vw)
 		st.IndentLevel--
-		st.WriteIndent()
+		st.WriteBlockEnd(true)
 	}
 	st.Writer.AppendByte('}')
 }
+
+// writeArrayItems writes the items from start to end.
+//
+// If collapseRepeated is true, the consecutive items that are equal to the previous written item are not written.
+// They are replaced by `<repeated N times>`, unless there is a single repeated item, because the marker would not be shorter.
+// The items are compared before they are written (see [arrayItemsEqual]), so nothing is written for the collapsed items.
+func writeArrayItems(st *State, v reflect.Value, start int, end int, showIndexes bool, collapseRepeated bool, vw ValueWriter) {
+	for i := start; i < end; {
+		writeArrayItem(st, v, i, showIndexes, vw)
+		repeated := 0
+		if collapseRepeated {
+			repeated = countArrayRepeated(v, i, end)
+		}
+		switch {
+		case repeated == 1:
+			writeArrayItem(st, v, i+1, showIndexes, vw)
+		case repeated > 1:
+			writeBlockRepeated(st, repeated)
+		}
+		i += 1 + repeated
+	}
+}
+
+func writeArrayItem(st *State, v reflect.Value, i int, showIndexes bool, vw ValueWriter) {
+	st.PushPath(PathElement{Kind: PathElementIndex, Index: i})
+	st.WriteBlockItemStart(i == 0)
+	if showIndexes {
+		st.Writer = strconv.AppendInt(st.Writer, int64(i), 10)
+		st.Writer.AppendString(": ")
+	}
+	vw.WriteValue(st, v.Index(i))
+	st.WriteBlockItemEnd()
+	st.PopPath()
+}
+
+// countArrayRepeated returns the number of consecutive items after the item i that are equal to it.
+func countArrayRepeated(v reflect.Value, i int, end int) int {
+	item := v.Index(i)
+	n := 0
+	for j := i + 1; j < end && arrayItemsEqual(item, v.Index(j)); j++ {
+		n++
+	}
+	return n
+}
+
+// arrayItemsEqual returns true if the items are equal, so their output is identical.
+//
+// The non-nil pointers are compared by their target, see [arrayValuesEqual].
+func arrayItemsEqual(a, b reflect.Value) bool {
+	if a.Kind() == reflect.Pointer && !a.IsNil() && !b.IsNil() && a.Pointer() != b.Pointer() {
+		a, b = a.Elem(), b.Elem()
+	}
+	return arrayValuesEqual(a, b)
+}
+
+// arrayValuesEqual returns true if the values of the same type are equal.
+//
+// It is similar to the == operator, but the floats are compared by bits (because -0 == 0 and NaN != NaN).
+// The values that are not comparable (e.g. slices, maps) are never equal.
+func arrayValuesEqual(a, b reflect.Value) bool {
+	switch a.Kind() { //nolint:exhaustive // The other kinds are not comparable.
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return math.Float64bits(a.Float()) == math.Float64bits(b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		ca, cb := a.Complex(), b.Complex()
+		return math.Float64bits(real(ca)) == math.Float64bits(real(cb)) && math.Float64bits(imag(ca)) == math.Float64bits(imag(cb))
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() && b.IsNil()
+		}
+		a, b = a.Elem(), b.Elem()
+		return a.Type() == b.Type() && arrayValuesEqual(a, b)
+	case reflect.Array:
+		for i := range a.Len() {
+			if !arrayValuesEqual(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := range a.NumField() {
+			if !arrayValuesEqual(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func writeBlockRepeated(st *State, repeated int) {
+	st.WriteBlockItemStart(false)
+	st.Writer.AppendString("<repeated ")
+	st.Writer = strconv.AppendInt(st.Writer, int64(repeated), 10)
+	st.Writer.AppendString(" times>")
+	if !st.Compact {
+		st.writeNewLine()
+	}
+}
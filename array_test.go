@@ -31,6 +31,23 @@ func init() {
 			},
 			IgnoreBenchmark: true,
 		},
+		{
+			Name:  "TruncateMiddle",
+			Value: [...]int{1, 2, 3, 4, 5},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Array.MaxLen = 2
+				vw.Kind.Array.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "CollapseRepeated",
+			Value: [...]int{0, 0, 0, 0},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Array.CollapseRepeated = true
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:            "UnknownType",
 			Value:           [...]any{1, 2, 3},
@@ -0,0 +1,144 @@
+package pretty
+
+import (
+	"encoding/hex"
+	"net/netip"
+	"reflect"
+	"slices"
+
+	"github.com/pierrre/go-libs/syncutil"
+)
+
+// ByteArrayFormat is the format used by [ByteArrayWriter].
+type ByteArrayFormat int
+
+const (
+	// ByteArrayFormatHex writes the array as compact hex, e.g. 0xdeadbeef.
+	// Long arrays are written with [hex.Dumper] (see [ByteArrayWriter.HexDumpMinLen]).
+	ByteArrayFormatHex ByteArrayFormat = iota
+	// ByteArrayFormatUUID writes a 16 bytes array as a UUID, e.g. 01234567-89ab-cdef-0123-456789abcdef.
+	ByteArrayFormatUUID
+	// ByteArrayFormatIP writes a 4 or 16 bytes array as an IP address, e.g. 192.168.0.1 or 2001:db8::1.
+	ByteArrayFormatIP
+	// ByteArrayFormatElements writes the array element by element, with the next [ValueWriter] (e.g. [ArrayWriter]).
+	ByteArrayFormatElements
+)
+
+// ByteArrayWriter is a [ValueWriter] that handles arrays of bytes (e.g. [16]byte or [32]byte).
+//
+// It is not enabled by default in [CommonWriter], see [CommonWriter.ByteArray].
+//
+// It should be created with [NewByteArrayWriter].
+type ByteArrayWriter struct {
+	// Formats contains the format by type.
+	// The types that are not registered use [ByteArrayFormatHex].
+	// If the format is not valid for the length of the array, it uses [ByteArrayFormatHex].
+	// Default: nil.
+	Formats map[reflect.Type]ByteArrayFormat
+	// HexDumpMinLen is the minimum length of the arrays written with [hex.Dumper] (except in compact mode).
+	// Default: 64.
+	HexDumpMinLen int
+	// ShowLen shows the len of the arrays written with [hex.Dumper].
+	// Default: true.
+	ShowLen bool
+	// MaxLen is the maximum length of the arrays written as hex, in bytes.
+	// If the array exceeds this length, it is truncated (see Truncate).
+	// Default: 0 (no limit).
+	MaxLen int
+	// Truncate is the mode used to truncate the arrays written as hex.
+	// Default: [TruncateModeHead].
+	Truncate TruncateMode
+}
+
+// NewByteArrayWriter creates a new [ByteArrayWriter] with default values.
+func NewByteArrayWriter() *ByteArrayWriter {
+	return &ByteArrayWriter{
+		Formats:       nil,
+		HexDumpMinLen: 64,
+		ShowLen:       true,
+		MaxLen:        0,
+		Truncate:      TruncateModeHead,
+	}
+}
+
+// WriteValue implements [ValueWriter].
+func (vw *ByteArrayWriter) WriteValue(st *State, v reflect.Value) bool {
+	typ := v.Type()
+	if !isByteArrayType(typ) || typ.Len() == 0 {
+		return false
+	}
+	format := vw.Formats[typ]
+	if format == ByteArrayFormatElements {
+		return false
+	}
+	var b []byte
+	if v.CanAddr() {
+		b = v.Bytes()
+	} else {
+		buf := byteArrayBufferPool.Get()
+		defer byteArrayBufferPool.Put(buf)
+		b = buf.copy(v)
+	}
+	switch {
+	case format == ByteArrayFormatUUID && len(b) == 16:
+		writeByteArrayUUID(st, b)
+	case format == ByteArrayFormatIP && (len(b) == 4 || len(b) == 16):
+		writeByteArrayIP(st, b)
+	case vw.HexDumpMinLen > 0 && len(b) >= vw.HexDumpMinLen && !st.Compact:
+		writeBytesHexDumpCommon(st, 0, b, vw.ShowLen, false, false, vw.MaxLen, vw.Truncate, false, BytesEncodingHexDump, 0)
+	default:
+		head, tail, omitted := getTruncateLens(len(b), vw.MaxLen, vw.Truncate)
+		writeBytesEncoded(st, infos{}, b[:head], BytesEncodingHex, omitted, b[len(b)-tail:])
+	}
+	return true
+}
+
+// byteArrayBuffer is a buffer used to copy the arrays that are not addressable.
+type byteArrayBuffer struct {
+	b []byte
+	v reflect.Value // Points to b.
+}
+
+var byteArrayBufferPool = syncutil.Pool[*byteArrayBuffer]{
+	New: func() *byteArrayBuffer {
+		buf := new(byteArrayBuffer)
+		buf.v = reflect.ValueOf(&buf.b).Elem()
+		return buf
+	},
+}
+
+func (buf *byteArrayBuffer) copy(v reflect.Value) []byte {
+	buf.b = slices.Grow(buf.b[:0], v.Len())[:v.Len()]
+	reflect.Copy(buf.v, v)
+	return buf.b
+}
+
+// Supports implements [SupportChecker].
+func (vw *ByteArrayWriter) Supports(typ reflect.Type) ValueWriter {
+	var res ValueWriter
+	if isByteArrayType(typ) && typ.Len() > 0 && vw.Formats[typ] != ByteArrayFormatElements {
+		res = vw
+	}
+	return res
+}
+
+func isByteArrayType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Array && typ.Elem().Kind() == reflect.Uint8
+}
+
+func writeByteArrayUUID(st *State, b []byte) {
+	st.Writer = hex.AppendEncode(st.Writer, b[0:4])
+	st.Writer.AppendByte('-')
+	st.Writer = hex.AppendEncode(st.Writer, b[4:6])
+	st.Writer.AppendByte('-')
+	st.Writer = hex.AppendEncode(st.Writer, b[6:8])
+	st.Writer.AppendByte('-')
+	st.Writer = hex.AppendEncode(st.Writer, b[8:10])
+	st.Writer.AppendByte('-')
+	st.Writer = hex.AppendEncode(st.Writer, b[10:16])
+}
+
+func writeByteArrayIP(st *State, b []byte) {
+	addr, _ := netip.AddrFromSlice(b)
+	st.Writer = addr.AppendTo(st.Writer)
+}
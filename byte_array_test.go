@@ -0,0 +1,165 @@
+package pretty_test
+
+import (
+	"reflect"
+
+	. "github.com/pierrre/pretty"
+	"github.com/pierrre/pretty/internal/prettytest"
+)
+
+func init() {
+	prettytest.AddCasesPrefix("ByteArray", []*prettytest.Case{
+		{
+			Name:            "Default",
+			Value:           [...]byte{0xde, 0xad, 0xbe, 0xef},
+			ConfigureWriter: configureTestByteArray,
+		},
+		{
+			Name:            "Addressable",
+			Value:           &[...]byte{0xde, 0xad, 0xbe, 0xef},
+			ConfigureWriter: configureTestByteArray,
+		},
+		{
+			Name:            "HexDump",
+			Value:           [64]byte{1, 2, 3},
+			ConfigureWriter: configureTestByteArray,
+		},
+		{
+			Name:            "HexDumpCompact",
+			Value:           [64]byte{1, 2, 3},
+			ConfigureWriter: configureTestByteArray,
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "HexDumpNotShowLen",
+			Value: [64]byte{1, 2, 3},
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestByteArray(vw)
+				vw.SetShowLen(false)
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "HexDumpMaxLen",
+			Value: [64]byte{1, 2, 3},
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestByteArray(vw)
+				vw.ByteArray.MaxLen = 16
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "MaxLen",
+			Value: [...]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestByteArray(vw)
+				vw.ByteArray.MaxLen = 4
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "MaxLenTruncateMiddle",
+			Value: [...]byte{1, 2, 3, 4, 5, 6, 7, 8},
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestByteArray(vw)
+				vw.ByteArray.MaxLen = 4
+				vw.ByteArray.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Empty",
+			Value:           [0]byte{},
+			ConfigureWriter: configureTestByteArray,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Struct",
+			Value:           testByteArrayStruct{Hash: [8]byte{1, 2, 3, 4, 5, 6, 7, 8}},
+			ConfigureWriter: configureTestByteArray,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "UUID",
+			Value:           testUUID{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef, 0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef},
+			ConfigureWriter: configureTestByteArrayFormats,
+		},
+		{
+			Name:            "UUIDInvalidLen",
+			Value:           testUUIDInvalidLen{1, 2, 3, 4},
+			ConfigureWriter: configureTestByteArrayFormats,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "IPv4",
+			Value:           testIPv4{192, 168, 0, 1},
+			ConfigureWriter: configureTestByteArrayFormats,
+		},
+		{
+			Name:            "IPv6",
+			Value:           testIPv6{0x20, 0x01, 0x0d, 0xb8, 15: 1},
+			ConfigureWriter: configureTestByteArrayFormats,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Elements",
+			Value:           testElements{1, 2, 3},
+			ConfigureWriter: configureTestByteArrayFormats,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Disabled",
+			Value:           [...]byte{1, 2, 3},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "SupportDisabled",
+			Value: [...]byte{1, 2, 3},
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestByteArray(vw)
+				vw.Support = nil
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Not",
+			Value: [...]int{1, 2, 3},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.ValueWriters = ValueWriters{NewByteArrayWriter()}
+			},
+			IgnoreBenchmark: true,
+		},
+	})
+}
+
+type testByteArrayStruct struct {
+	Hash [8]byte
+}
+
+type testUUID [16]byte
+
+type testUUIDInvalidLen [4]byte
+
+type testIPv4 [4]byte
+
+type testIPv6 [16]byte
+
+type testElements [3]byte
+
+func configureTestByteArray(vw *CommonWriter) {
+	vw.ByteArray = NewByteArrayWriter()
+}
+
+func configureTestByteArrayFormats(vw *CommonWriter) {
+	configureTestByteArray(vw)
+	vw.ByteArray.Formats = map[reflect.Type]ByteArrayFormat{
+		reflect.TypeFor[testUUID]():           ByteArrayFormatUUID,
+		reflect.TypeFor[testUUIDInvalidLen](): ByteArrayFormatUUID,
+		reflect.TypeFor[testIPv4]():           ByteArrayFormatIP,
+		reflect.TypeFor[testIPv6]():           ByteArrayFormatIP,
+		reflect.TypeFor[testElements]():       ByteArrayFormatElements,
+	}
+}
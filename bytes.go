@@ -1,20 +1,52 @@
 package pretty
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/hex"
 	"io"
 	"reflect"
+	"strconv"
+	"unicode/utf8"
 
 	"github.com/pierrre/go-libs/reflectutil"
 	"github.com/pierrre/go-libs/syncutil"
-	"github.com/pierrre/pretty/internal/indent"
 	"github.com/pierrre/pretty/internal/itfassert"
 )
 
 var bytesType = reflect.TypeFor[[]byte]()
 
+// BytesEncoding is the encoding used to write bytes.
+type BytesEncoding int
+
+const (
+	// BytesEncodingHexDump writes the bytes with [hex.Dumper] (or hex encoded in compact mode).
+	BytesEncodingHexDump BytesEncoding = iota
+	// BytesEncodingAuto selects the encoding depending on the content:
+	//   - printable UTF-8 text: [BytesEncodingText]
+	//   - short binary data (see HexMaxLen): [BytesEncodingHex]
+	//   - large binary data: [BytesEncodingHexDump]
+	BytesEncodingAuto
+	// BytesEncodingText writes the bytes as a quoted string, e.g. "test".
+	BytesEncodingText
+	// BytesEncodingHex writes the bytes as compact hex, e.g. 0xdeadbeef.
+	BytesEncodingHex
+	// BytesEncodingBase64 writes the bytes with the standard base64 encoding, e.g. base64:3q2+7w==.
+	BytesEncodingBase64
+	// BytesEncodingBase64URL writes the bytes with the URL base64 encoding, e.g. base64url:3q2-7w==.
+	BytesEncodingBase64URL
+)
+
+// bytesEncodingHexCompact writes the bytes as hex without prefix.
+// It is used for [BytesEncodingHexDump] in compact mode.
+const bytesEncodingHexCompact BytesEncoding = -1
+
+const defaultBytesHexMaxLen = 32
+
 // BytesHexDumpWriter is a [ValueWriter] that handles []byte and writes them with [hex.Dumper].
 //
+// Other encodings can be selected with [BytesHexDumpWriter.Encoding].
+//
 // It should be created with [NewBytesHexDumpWriter].
 type BytesHexDumpWriter struct {
 	// ShowLen shows the len.
@@ -27,18 +59,36 @@ type BytesHexDumpWriter struct {
 	// Default: false.
 	ShowAddr bool
 	// MaxLen is the maximum length of the bytes, in bytes.
-	// If the byte slice exceeds this length, it is truncated.
+	// If the byte slice exceeds this length, it is truncated (see Truncate).
 	// Default: 0 (no limit).
 	MaxLen int
+	// Truncate is the mode used to truncate the bytes.
+	// With [TruncateModeMiddle] and [BytesEncodingHexDump], the tail starts at a multiple of 16 bytes.
+	// Default: [TruncateModeHead].
+	Truncate TruncateMode
+	// Squeeze replaces the consecutive identical lines of [BytesEncodingHexDump] by a single "*" line, like `hexdump -C`.
+	// Default: false.
+	Squeeze bool
+	// Encoding is the encoding of the bytes.
+	// Default: [BytesEncodingHexDump].
+	Encoding BytesEncoding
+	// HexMaxLen is the maximum length of the binary data written as compact hex with [BytesEncodingAuto].
+	// The larger binary data is written with [hex.Dumper].
+	// Default: 32.
+	HexMaxLen int
 }
 
 // NewBytesHexDumpWriter creates a new [BytesHexDumpWriter].
 func NewBytesHexDumpWriter() *BytesHexDumpWriter {
 	return &BytesHexDumpWriter{
-		ShowLen:  true,
-		ShowCap:  true,
-		ShowAddr: false,
-		MaxLen:   0,
+		ShowLen:   true,
+		ShowCap:   true,
+		ShowAddr:  false,
+		MaxLen:    0,
+		Truncate:  TruncateModeHead,
+		Squeeze:   false,
+		Encoding:  BytesEncodingHexDump,
+		HexMaxLen: defaultBytesHexMaxLen,
 	}
 }
 
@@ -51,7 +101,7 @@ func (vw *BytesHexDumpWriter) WriteValue(st *State, v reflect.Value) bool {
 		return true
 	}
 	b := v.Bytes()
-	writeBytesHexDumpCommon(st, v, b, vw.ShowLen, vw.ShowCap, vw.ShowAddr, vw.MaxLen)
+	writeBytesHexDumpCommon(st, uintptr(v.UnsafePointer()), b, vw.ShowLen, vw.ShowCap, vw.ShowAddr, vw.MaxLen, vw.Truncate, vw.Squeeze, vw.Encoding, vw.HexMaxLen)
 	return true
 }
 
@@ -73,6 +123,8 @@ var bytesableImplementsCache = reflectutil.NewImplementsCacheFor[Bytesable]()
 
 // BytesableHexDumpWriter is a [ValueWriter] that handles [Bytesable] and writes them with [hex.Dumper].
 //
+// Other encodings can be selected with [BytesableHexDumpWriter.Encoding].
+//
 // If [Bytesable.Bytes] panics, [BytesableHexDumpWriter.WriteValue] returns false.
 //
 // It should be created with [NewBytesableHexDumpWriter].
@@ -87,18 +139,36 @@ type BytesableHexDumpWriter struct {
 	// Default: false.
 	ShowAddr bool
 	// MaxLen is the maximum length of the bytes, in bytes.
-	// If the byte slice exceeds this length, it is truncated.
+	// If the byte slice exceeds this length, it is truncated (see Truncate).
 	// Default: 0 (no limit).
 	MaxLen int
+	// Truncate is the mode used to truncate the bytes.
+	// With [TruncateModeMiddle] and [BytesEncodingHexDump], the tail starts at a multiple of 16 bytes.
+	// Default: [TruncateModeHead].
+	Truncate TruncateMode
+	// Squeeze replaces the consecutive identical lines of [BytesEncodingHexDump] by a single "*" line, like `hexdump -C`.
+	// Default: false.
+	Squeeze bool
+	// Encoding is the encoding of the bytes.
+	// Default: [BytesEncodingHexDump].
+	Encoding BytesEncoding
+	// HexMaxLen is the maximum length of the binary data written as compact hex with [BytesEncodingAuto].
+	// The larger binary data is written with [hex.Dumper].
+	// Default: 32.
+	HexMaxLen int
 }
 
 // NewBytesableHexDumpWriter creates a new [BytesableHexDumpWriter].
 func NewBytesableHexDumpWriter() *BytesableHexDumpWriter {
 	return &BytesableHexDumpWriter{
-		ShowLen:  true,
-		ShowCap:  true,
-		ShowAddr: false,
-		MaxLen:   0,
+		ShowLen:   true,
+		ShowCap:   true,
+		ShowAddr:  false,
+		MaxLen:    0,
+		Truncate:  TruncateModeHead,
+		Squeeze:   false,
+		Encoding:  BytesEncodingHexDump,
+		HexMaxLen: defaultBytesHexMaxLen,
 	}
 }
 
@@ -131,7 +201,7 @@ func (vw *BytesableHexDumpWriter) WriteValue(st *State, v reflect.Value) bool {
 		writeNil(st)
 		return true
 	}
-	writeBytesHexDumpCommon(st, reflect.ValueOf(b), b, vw.ShowLen, vw.ShowCap, vw.ShowAddr, vw.MaxLen)
+	writeBytesHexDumpCommon(st, uintptr(reflect.ValueOf(b).UnsafePointer()), b, vw.ShowLen, vw.ShowCap, vw.ShowAddr, vw.MaxLen, vw.Truncate, vw.Squeeze, vw.Encoding, vw.HexMaxLen)
 	return true
 }
 
@@ -144,40 +214,181 @@ func (vw *BytesableHexDumpWriter) Supports(typ reflect.Type) ValueWriter {
 	return res
 }
 
-func writeBytesHexDumpCommon(st *State, v reflect.Value, b []byte, showLen bool, showCap bool, showAddr bool, maxLen int) {
-	infos{
+func writeBytesHexDumpCommon(st *State, addr uintptr, b []byte, showLen bool, showCap bool, showAddr bool, maxLen int, truncate TruncateMode, squeeze bool, enc BytesEncoding, hexMaxLen int) {
+	is := infos{
 		showLen:  showLen,
 		len:      len(b),
 		showCap:  showCap,
 		cap:      cap(b),
 		showAddr: showAddr,
-		addr:     uintptr(v.UnsafePointer()),
-	}.write(st)
-	truncated := false
-	if maxLen > 0 && len(b) > maxLen {
-		b = b[:maxLen]
-		truncated = true
-	}
-	if len(b) == 0 {
+		addr:     addr,
+	}
+	if enc == BytesEncodingAuto {
+		enc = getBytesAutoEncoding(st, b, hexMaxLen)
+	}
+	if enc == BytesEncodingHexDump && st.Compact {
+		enc = bytesEncodingHexCompact
+	}
+	head, tail, omitted := getTruncateLens(len(b), maxLen, truncate)
+	if enc == BytesEncodingHexDump && tail > 0 {
+		tail = len(b) - min(int(alignUp(uintptr(len(b)-tail), 16)), len(b)) // The tail lines are aligned with the head lines.
+		omitted = len(b) - head - tail
+	}
+	bt := b[len(b)-tail:]
+	b = b[:head]
+	if enc != BytesEncodingHexDump {
+		writeBytesEncoded(st, is, b, enc, omitted, bt)
+		return
+	}
+	is.write(st)
+	if len(b) == 0 && omitted == 0 {
 		return
 	}
-	st.Writer.AppendByte('\n')
+	st.writeNewLine()
 	st.IndentLevel++
 	defer func() {
 		st.IndentLevel--
 		st.WriteIndent()
 	}()
-	iw := indent.NewWriter(&st.Writer, st.IndentString, st.IndentLevel, false)
+	iw := st.newIndentWriter()
 	defer iw.Release()
-	e := getHexDumperPoolEntry(iw)
-	defer releaseHexDumperPoolEntry(e)
-	d := e.dumper
-	_, _ = d.Write(b)
-	_ = d.Close()
-	if truncated {
+	if squeeze {
+		bw := bytesWriterPool.Get()
+		*bw = appendHexDump(*bw, b, 0, true)
+		_, _ = iw.Write(*bw)
+		bytesWriterPool.Put(bw)
+	} else {
+		e := getHexDumperPoolEntry(iw)
+		defer releaseHexDumperPoolEntry(e)
+		d := e.dumper
+		_, _ = d.Write(b)
+		_ = d.Close()
+	}
+	if omitted > 0 {
 		st.WriteIndent()
-		writeTruncated(st)
-		st.Writer.AppendByte('\n')
+		writeOmitted(st, omitted)
+		st.writeNewLine()
+	}
+	if len(bt) > 0 {
+		bw := bytesWriterPool.Get()
+		*bw = appendHexDump(*bw, bt, head+omitted, squeeze)
+		_, _ = iw.Write(*bw)
+		bytesWriterPool.Put(bw)
+	}
+}
+
+// appendHexDump appends the bytes in the same format as [hex.Dumper], starting at the given offset.
+//
+// The offset must be a multiple of 16.
+// If squeeze is true, the consecutive identical lines are replaced by a single "*" line, like `hexdump -C`.
+// If the last lines are squeezed, it is followed by a line with the offset of the end.
+func appendHexDump(dst []byte, b []byte, offset int, squeeze bool) []byte {
+	end := offset + len(b)
+	var prev []byte
+	squeezed := false
+	for ; len(b) > 0; offset += 16 {
+		line := b[:min(len(b), 16)]
+		b = b[len(line):]
+		if squeeze && bytes.Equal(line, prev) {
+			if !squeezed {
+				dst = append(dst, "*\n"...)
+				squeezed = true
+			}
+			continue
+		}
+		prev = line
+		squeezed = false
+		dst = appendHexDumpOffset(dst, offset)
+		dst = append(dst, "  "...)
+		for i := range 16 {
+			if i < len(line) {
+				dst = append(dst, hexDigits[line[i]>>4], hexDigits[line[i]&0xf], ' ')
+			} else {
+				dst = append(dst, "   "...)
+			}
+			if i == 7 {
+				dst = append(dst, ' ')
+			}
+		}
+		dst = append(dst, " |"...)
+		for _, c := range line {
+			if c < 32 || c > 126 {
+				c = '.'
+			}
+			dst = append(dst, c)
+		}
+		dst = append(dst, "|\n"...)
+	}
+	if squeezed {
+		// Writes the offset of the end, so the length of the squeezed lines is known.
+		dst = appendHexDumpOffset(dst, end)
+		dst = append(dst, '\n')
+	}
+	return dst
+}
+
+func appendHexDumpOffset(dst []byte, offset int) []byte {
+	dst = append(dst, hexDigits[offset>>28&0xf], hexDigits[offset>>24&0xf], hexDigits[offset>>20&0xf], hexDigits[offset>>16&0xf])
+	return append(dst, hexDigits[offset>>12&0xf], hexDigits[offset>>8&0xf], hexDigits[offset>>4&0xf], hexDigits[offset&0xf])
+}
+
+func getBytesAutoEncoding(st *State, b []byte, hexMaxLen int) BytesEncoding {
+	switch {
+	case isBytesText(b):
+		return BytesEncodingText
+	case len(b) <= hexMaxLen || st.Compact:
+		return BytesEncodingHex
+	}
+	return BytesEncodingHexDump
+}
+
+// isBytesText returns true if the bytes are printable UTF-8 text.
+func isBytesText(b []byte) bool {
+	for len(b) > 0 {
+		r, size := utf8.DecodeRune(b)
+		if r == utf8.RuneError && size == 1 {
+			return false
+		}
+		if r != '\n' && r != '\r' && r != '\t' && !strconv.IsPrint(r) {
+			return false
+		}
+		b = b[size:]
+	}
+	return true
+}
+
+func writeBytesEncoded(st *State, is infos, b []byte, enc BytesEncoding, omitted int, tail []byte) {
+	if len(b) == 0 && omitted == 0 {
+		is.write(st)
+		return
+	}
+	is.writeWithTrailingSpace(st)
+	writeBytesEncodedPart(st, b, enc)
+	if omitted > 0 {
+		st.Writer.AppendByte(' ')
+		writeOmitted(st, omitted)
+		if len(tail) > 0 {
+			st.Writer.AppendByte(' ')
+			writeBytesEncodedPart(st, tail, enc)
+		}
+	}
+}
+
+func writeBytesEncodedPart(st *State, b []byte, enc BytesEncoding) {
+	switch enc { //nolint:exhaustive // The other encodings are handled by the caller.
+	case BytesEncodingText:
+		st.Writer = strconv.AppendQuote(st.Writer, string(b))
+	case BytesEncodingHex:
+		st.Writer.AppendString("0x")
+		st.Writer = hex.AppendEncode(st.Writer, b)
+	case bytesEncodingHexCompact:
+		st.Writer = hex.AppendEncode(st.Writer, b)
+	case BytesEncodingBase64:
+		st.Writer.AppendString("base64:")
+		st.Writer = base64.StdEncoding.AppendEncode(st.Writer, b)
+	case BytesEncodingBase64URL:
+		st.Writer.AppendString("base64url:")
+		st.Writer = base64.URLEncoding.AppendEncode(st.Writer, b)
 	}
 }
 
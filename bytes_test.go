@@ -3,6 +3,8 @@ package pretty_test
 import (
 	"bytes"
 	"reflect"
+	"slices"
+	"strings"
 
 	. "github.com/pierrre/pretty"
 	"github.com/pierrre/pretty/internal/prettytest"
@@ -32,6 +34,131 @@ func init() {
 			},
 			IgnoreBenchmark: true,
 		},
+		{
+			Name:  "TruncateMiddle",
+			Value: []byte(strings.Repeat("0123456789", 10)),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.MaxLen = 40
+				vw.BytesHexDump.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "TruncateMiddleCompact",
+			Value: []byte("abcdefgh"),
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.MaxLen = 4
+				vw.BytesHexDump.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Squeeze",
+			Value: slices.Concat(bytes.Repeat([]byte("a"), 16), make([]byte, 64), bytes.Repeat([]byte("b"), 20)),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.Squeeze = true
+			},
+		},
+		{
+			Name:  "SqueezeEnd",
+			Value: slices.Concat(bytes.Repeat([]byte("a"), 16), make([]byte, 48)),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.Squeeze = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "SqueezeTruncateMiddle",
+			Value: make([]byte, 256),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.Squeeze = true
+				vw.BytesHexDump.MaxLen = 128
+				vw.BytesHexDump.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Compact",
+			Value: []byte("test"),
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.MaxLen = 2
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "EncodingAuto",
+			Value: [][]byte{
+				[]byte("test\n"),
+				{0xde, 0xad, 0xbe, 0xef},
+				bytes.Repeat([]byte{0xff}, 20),
+				{},
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.Encoding = BytesEncodingAuto
+				vw.BytesHexDump.HexMaxLen = 16
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "EncodingAutoCompact",
+			Value: [][]byte{[]byte("test"), bytes.Repeat([]byte{0xff}, 40)},
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.Encoding = BytesEncodingAuto
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "EncodingHex",
+			Value: []byte{0xde, 0xad, 0xbe, 0xef},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.Encoding = BytesEncodingHex
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "EncodingBase64",
+			Value: []byte{0xde, 0xad, 0xbe, 0xef},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.Encoding = BytesEncodingBase64
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "EncodingBase64URL",
+			Value: []byte{0xde, 0xad, 0xbe, 0xef},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.Encoding = BytesEncodingBase64URL
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "EncodingTextTruncated",
+			Value: []byte("test"),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.Encoding = BytesEncodingText
+				vw.BytesHexDump.MaxLen = 2
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "EncodingTextTruncateMiddle",
+			Value: []byte("abcdefgh"),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesHexDump.Encoding = BytesEncodingText
+				vw.BytesHexDump.MaxLen = 4
+				vw.BytesHexDump.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:  "ShowCap",
 			Value: []byte("test"),
@@ -84,6 +211,14 @@ func init() {
 			Value:           (*testBytesable)(nil),
 			IgnoreBenchmark: true,
 		},
+		{
+			Name:  "EncodingAuto",
+			Value: &testBytesable{b: []byte("test")},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesableHexDump.ValueWriter.Encoding = BytesEncodingAuto
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:            "NilBytes",
 			Value:           &testBytesable{},
@@ -97,6 +232,15 @@ func init() {
 			},
 			IgnoreBenchmark: true,
 		},
+		{
+			Name:  "TruncateMiddle",
+			Value: &testBytesable{b: []byte("abcdefgh")},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.BytesableHexDump.ValueWriter.MaxLen = 4
+				vw.BytesableHexDump.ValueWriter.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:  "ReflectValue",
 			Value: reflect.ValueOf(123),
@@ -29,10 +29,14 @@ type CommonWriter struct {
 	MaxDepth         *MaxDepthWriter
 	CanInterface     *CanInterfaceWriter
 	Type             *TypeWriter
+	Size             *SizeWriter
 	ByType           ByTypeWriters
 	ValueWriters     ValueWriters
+	Unit             *UnitWriter
 	Support          *SupportWriter
+	Enum             *EnumWriter
 	Time             *TimeWriter
+	JSON             *JSONWriter
 	BytesHexDump     *BytesHexDumpWriter
 	MathBig          *MathBigWriter
 	Reflect          *ReflectWriter
@@ -43,7 +47,17 @@ type CommonWriter struct {
 	BytesableHexDump *FilterWriter[*BytesableHexDumpWriter]
 	GoStringer       *FilterWriter[*GoStringerWriter]
 	Stringer         *FilterWriter[*StringerWriter]
-	Kind             *KindWriter
+	// Matrix is opt-in, it can be enabled with [NewMatrixWriter].
+	// Default: nil.
+	Matrix *MatrixWriter
+	// Table is opt-in, it can be enabled with [NewTableWriter].
+	// Default: nil.
+	Table *TableWriter
+	// ByteArray is opt-in, it can be enabled with [NewByteArrayWriter].
+	// The byte arrays are written element by element by default, as the other arrays.
+	// Default: nil.
+	ByteArray *ByteArrayWriter
+	Kind      *KindWriter
 }
 
 // NewCommonWriter creates a new [CommonWriter] initialized with default values.
@@ -59,7 +73,9 @@ func NewCommonWriter() *CommonWriter {
 	vw.Support.Checkers = []SupportChecker{
 		vw,
 	}
+	vw.Enum = NewEnumWriter()
 	vw.Time = NewTimeWriter()
+	vw.Unit = NewUnitWriter(vw.Support, vw.Time.Time)
 	vw.BytesHexDump = NewBytesHexDumpWriter()
 	vw.MathBig = NewMathBigWriter()
 	vw.Reflect = NewReflectWriter(vw)
@@ -82,6 +98,9 @@ func (vw *CommonWriter) SetShowLen(show bool) {
 		vw.Kind.Slice.ShowLen = show
 		vw.Kind.String.ShowLen = show
 	}
+	if vw.JSON != nil {
+		vw.JSON.ShowLen = show
+	}
 	if vw.BytesHexDump != nil {
 		vw.BytesHexDump.ShowLen = show
 	}
@@ -91,6 +110,15 @@ func (vw *CommonWriter) SetShowLen(show bool) {
 	if vw.Stringer != nil {
 		vw.Stringer.ValueWriter.ShowLen = show
 	}
+	if vw.Matrix != nil {
+		vw.Matrix.ShowLen = show
+	}
+	if vw.Table != nil {
+		vw.Table.ShowLen = show
+	}
+	if vw.ByteArray != nil {
+		vw.ByteArray.ShowLen = show
+	}
 }
 
 // SetShowCap sets ShowCap on all [ValueWriter]s that support it.
@@ -134,6 +162,26 @@ func (vw *CommonWriter) SetShowIndexes(show bool) {
 		vw.Kind.Array.ShowIndexes = show
 		vw.Kind.Slice.ShowIndexes = show
 	}
+	if vw.Matrix != nil {
+		vw.Matrix.ShowIndexes = show
+	}
+	if vw.Table != nil {
+		vw.Table.ShowIndexes = show
+	}
+}
+
+// SetNumberFormat sets NumberFormat on all [ValueWriter]s that support it.
+func (vw *CommonWriter) SetNumberFormat(nf NumberFormat) {
+	if vw.Kind != nil {
+		vw.Kind.Int.NumberFormat = nf
+		vw.Kind.Uint.NumberFormat = nf
+		vw.Kind.Uintptr.NumberFormat = nf
+		vw.Kind.Float.NumberFormat = nf
+		vw.Kind.Complex.NumberFormat = nf
+	}
+	if vw.MathBig != nil && vw.MathBig.Int != nil {
+		vw.MathBig.Int.NumberFormat = nf
+	}
 }
 
 // ConfigureTest configures the [CommonWriter] for testing.
@@ -151,7 +199,11 @@ func (vw *CommonWriter) ConfigureTest(enabled bool) {
 
 // WriteValue implements [ValueWriter].
 func (vw *CommonWriter) WriteValue(st *State, v reflect.Value) bool {
+	var itfType reflect.Type
 	if vw.UnwrapInterface != nil {
+		if v.Kind() == reflect.Interface {
+			itfType = v.Type() // Used by MaxDepth.MaxByType.
+		}
 		var isNil bool
 		v, isNil = vw.UnwrapInterface.unwrapInterface(st, v)
 		if isNil {
@@ -168,8 +220,8 @@ func (vw *CommonWriter) WriteValue(st *State, v reflect.Value) bool {
 		}
 	}
 	if vw.MaxDepth != nil {
-		maxReached := vw.MaxDepth.checkMaxDepth(st)
-		defer vw.MaxDepth.postMaxDepth(st)
+		maxReached, previousMaxDepth := vw.MaxDepth.checkMaxDepth(st, v, itfType)
+		defer vw.MaxDepth.postMaxDepth(st, previousMaxDepth)
 		if maxReached {
 			return true
 		}
@@ -181,6 +233,12 @@ func (vw *CommonWriter) WriteValue(st *State, v reflect.Value) bool {
 		knownType := vw.Type.writeType(st, v)
 		defer vw.Type.postType(st, knownType)
 	}
+	if vw.Size != nil {
+		previousSize := vw.Size.writeSize(st, v)
+		ok := vw.writeValue(st, v)
+		vw.Size.postSize(st, previousSize, ok)
+		return ok
+	}
 	return vw.writeValue(st, v)
 }
 
@@ -192,12 +250,21 @@ func (vw *CommonWriter) writeValue(st *State, v reflect.Value) bool {
 	if len(vw.ValueWriters) != 0 && vw.ValueWriters.WriteValue(st, v) {
 		return true
 	}
+	if vw.Unit != nil && vw.Unit.WriteValue(st, v) {
+		return true
+	}
 	if vw.Support != nil && vw.Support.WriteValue(st, v) {
 		return true
 	}
+	if vw.Enum != nil && vw.Enum.WriteValue(st, v) {
+		return true
+	}
 	if vw.Time != nil && vw.Time.WriteValue(st, v) {
 		return true
 	}
+	if vw.JSON != nil && vw.JSON.WriteValue(st, v) {
+		return true
+	}
 	if vw.BytesHexDump != nil && vw.BytesHexDump.WriteValue(st, v) {
 		return true
 	}
@@ -228,6 +295,15 @@ func (vw *CommonWriter) writeValue(st *State, v reflect.Value) bool {
 	if vw.Stringer != nil && vw.Stringer.WriteValue(st, v) {
 		return true
 	}
+	if vw.Matrix != nil && vw.Matrix.WriteValue(st, v) {
+		return true
+	}
+	if vw.Table != nil && vw.Table.WriteValue(st, v) {
+		return true
+	}
+	if vw.ByteArray != nil && vw.ByteArray.WriteValue(st, v) {
+		return true
+	}
 	if vw.Kind != nil && vw.Kind.WriteValue(st, v) {
 		return true
 	}
@@ -238,9 +314,15 @@ func (vw *CommonWriter) writeValue(st *State, v reflect.Value) bool {
 //
 //nolint:gocyclo // We need to call all [SupportChecker].
 func (vw *CommonWriter) Supports(typ reflect.Type) ValueWriter {
+	if w := callSupportCheckerPointer(vw.Enum, typ); w != nil {
+		return w
+	}
 	if w := callSupportCheckerPointer(vw.Time, typ); w != nil {
 		return w
 	}
+	if w := callSupportCheckerPointer(vw.JSON, typ); w != nil {
+		return w
+	}
 	if w := callSupportCheckerPointer(vw.BytesHexDump, typ); w != nil {
 		return w
 	}
@@ -271,6 +353,15 @@ func (vw *CommonWriter) Supports(typ reflect.Type) ValueWriter {
 	if w := callSupportCheckerPointer(vw.Stringer, typ); w != nil {
 		return w
 	}
+	if w := callSupportCheckerPointer(vw.Matrix, typ); w != nil {
+		return w
+	}
+	if w := callSupportCheckerPointer(vw.Table, typ); w != nil {
+		return w
+	}
+	if w := callSupportCheckerPointer(vw.ByteArray, typ); w != nil {
+		return w
+	}
 	if w := callSupportCheckerPointer(vw.Kind, typ); w != nil {
 		return w
 	}
@@ -2,7 +2,6 @@ package pretty
 
 import (
 	"reflect"
-	"strconv"
 )
 
 // ComplexWriter is a [ValueWriter] that handles complex values.
@@ -15,13 +14,18 @@ type ComplexWriter struct {
 	// Precision is the precision used to format the complex value.
 	// Default: -1.
 	Precision int
+	// NumberFormat is the format of the number.
+	// Only GroupSeparator is used.
+	// Default: zero value.
+	NumberFormat NumberFormat
 }
 
 // NewComplexWriter creates a new [ComplexWriter] with default values.
 func NewComplexWriter() *ComplexWriter {
 	return &ComplexWriter{
-		Format:    'g',
-		Precision: -1,
+		Format:       'g',
+		Precision:    -1,
+		NumberFormat: NumberFormat{},
 	}
 }
 
@@ -36,7 +40,7 @@ func (vw *ComplexWriter) WriteValue(st *State, v reflect.Value) bool {
 	default:
 		return false
 	}
-	st.Writer = appendComplex(st.Writer, v.Complex(), vw.Format, vw.Precision, bitSize)
+	st.Writer = vw.NumberFormat.appendComplex(st.Writer, v.Complex(), vw.Format, vw.Precision, bitSize)
 	return true
 }
 
@@ -50,12 +54,12 @@ func (vw *ComplexWriter) Supports(typ reflect.Type) ValueWriter {
 	return res
 }
 
-func appendComplex(dst []byte, c complex128, fmt byte, prec, bitSize int) []byte {
+func (nf NumberFormat) appendComplex(dst []byte, c complex128, fmt byte, prec, bitSize int) []byte {
 	bitSize >>= 1 // complex64 uses float32 internally
 	dst = append(dst, '(')
-	dst = strconv.AppendFloat(dst, real(c), fmt, prec, bitSize)
+	dst = nf.appendFloat(dst, real(c), fmt, prec, bitSize)
 	i := len(dst)
-	dst = strconv.AppendFloat(dst, imag(c), fmt, prec, bitSize)
+	dst = nf.appendFloat(dst, imag(c), fmt, prec, bitSize)
 	// Check if imaginary part has a sign. If not, add one.
 	if dst[i] != '+' && dst[i] != '-' {
 		dst = append(dst, 0)
@@ -0,0 +1,104 @@
+package pretty
+
+import (
+	"reflect"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/pierrre/go-libs/syncutil"
+)
+
+// integer is the constraint of the integer types supported by [RegisterEnum] and [RegisterFlags].
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// enumNames contains the names of an enum type, by value.
+//
+// The signed values are stored as their two's complement representation.
+type enumNames map[uint64]string
+
+var (
+	enumRegistry   syncutil.Map[reflect.Type, enumNames]
+	enumRegistered atomic.Bool
+)
+
+// RegisterEnum registers the names of the values of an enum type, for [EnumWriter].
+//
+// It replaces the previous registration of the type.
+// It should be called during initialization, because [SupportWriter] caches the [ValueWriter] by type.
+func RegisterEnum[T integer](names map[T]string) {
+	ns := make(enumNames, len(names))
+	for v, n := range names {
+		ns[uint64(v)] = n
+	}
+	enumRegistry.Store(reflect.TypeFor[T](), ns)
+	enumRegistered.Store(true)
+}
+
+func getEnumNames(typ reflect.Type) (enumNames, bool) {
+	if !enumRegistered.Load() {
+		return nil, false // Fast path for the types that are not registered.
+	}
+	return enumRegistry.Load(typ)
+}
+
+// EnumWriter is a [ValueWriter] that handles the enum types registered with [RegisterEnum].
+//
+// It writes the name and the value, e.g. `Name (3)`.
+// The unknown values are written as `<unknown> (42)`.
+//
+// It should be created with [NewEnumWriter].
+type EnumWriter struct {
+	// ShowValue shows the value after the name.
+	// It is always shown for unknown values.
+	// Default: true.
+	ShowValue bool
+}
+
+// NewEnumWriter creates a new [EnumWriter] with default values.
+func NewEnumWriter() *EnumWriter {
+	return &EnumWriter{
+		ShowValue: true,
+	}
+}
+
+// WriteValue implements [ValueWriter].
+func (vw *EnumWriter) WriteValue(st *State, v reflect.Value) bool {
+	names, ok := getEnumNames(v.Type())
+	if !ok {
+		return false
+	}
+	signed := v.CanInt()
+	var key uint64
+	if signed {
+		key = uint64(v.Int())
+	} else {
+		key = v.Uint()
+	}
+	name, ok := names[key]
+	if !ok {
+		name = "<unknown>"
+	} else if !vw.ShowValue {
+		st.Writer.AppendString(name)
+		return true
+	}
+	st.Writer.AppendString(name)
+	st.Writer.AppendString(" (")
+	if signed {
+		st.Writer = strconv.AppendInt(st.Writer, int64(key), 10)
+	} else {
+		st.Writer = strconv.AppendUint(st.Writer, key, 10)
+	}
+	st.Writer.AppendByte(')')
+	return true
+}
+
+// Supports implements [SupportChecker].
+func (vw *EnumWriter) Supports(typ reflect.Type) ValueWriter {
+	var res ValueWriter
+	if _, ok := getEnumNames(typ); ok {
+		res = vw
+	}
+	return res
+}
@@ -0,0 +1,100 @@
+package pretty_test
+
+import (
+	. "github.com/pierrre/pretty"
+	"github.com/pierrre/pretty/internal/prettytest"
+)
+
+func init() {
+	RegisterEnum(map[testEnum]string{
+		testEnumA: "A",
+		testEnumB: "B",
+		testEnumC: "C",
+		-1:        "Invalid",
+	})
+	RegisterEnum(map[testEnumUint]string{
+		1: "One",
+		2: "Two",
+	})
+	prettytest.AddCasesPrefix("Enum", []*prettytest.Case{
+		{
+			Name:  "Default",
+			Value: testEnumC,
+		},
+		{
+			Name:            "Negative",
+			Value:           testEnum(-1),
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Unknown",
+			Value:           testEnum(42),
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Unsigned",
+			Value:           testEnumUint(2),
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "UnsignedUnknown",
+			Value:           testEnumUint(42),
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "ShowValueDisabled",
+			Value: []testEnum{testEnumA, 42},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Enum.ShowValue = false
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "Struct",
+			Value: testEnumStruct{
+				Enum:  testEnumB,
+				Other: 2,
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "SupportDisabled",
+			Value: testEnumC,
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Support = nil
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Disabled",
+			Value: testEnumC,
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Enum = nil
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Not",
+			Value: 2,
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.ValueWriters = ValueWriters{vw.Enum}
+			},
+			IgnoreBenchmark: true,
+		},
+	})
+}
+
+type testEnum int
+
+const (
+	testEnumA testEnum = iota + 1
+	testEnumB
+	testEnumC
+)
+
+type testEnumUint uint8
+
+type testEnumStruct struct {
+	Enum  testEnum
+	Other int
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/pierrre/go-libs/reflectutil"
 	"github.com/pierrre/go-libs/runtimeutil"
-	"github.com/pierrre/pretty/internal/indent"
 	"github.com/pierrre/pretty/internal/itfassert"
 )
 
@@ -50,12 +49,14 @@ func (vw *ErrorWriter) WriteValue(st *State, v reflect.Value) bool {
 	if !ok {
 		return false
 	}
-	st.Writer.AppendString("{\n")
+	st.Writer.AppendByte('{')
 	st.IndentLevel++
-	st.WriteIndent()
+	st.PushPath(PathElement{Kind: PathElementMethod, Name: "Error"})
+	st.WriteBlockItemStart(true)
 	st.Writer.AppendString("Error(): ")
 	st.Writer = strconv.AppendQuote(st.Writer, err.Error())
-	st.Writer.AppendString(",\n")
+	st.WriteBlockItemEnd()
+	st.PopPath()
 	if vw.ShowVerbose {
 		vw.WriteVerboseError(st, err)
 	}
@@ -69,24 +70,28 @@ func (vw *ErrorWriter) WriteValue(st *State, v reflect.Value) bool {
 	case interface{ Unwrap() error }:
 		e := err.Unwrap()
 		if e != nil {
-			st.WriteIndent()
+			st.PushPath(PathElement{Kind: PathElementMethod, Name: "Unwrap"})
+			st.WriteBlockItemStart(false)
 			st.Writer.AppendString("Unwrap(): ")
 			st.KnownType = false // We want to show the type of the unwrapped error.
 			vw.ValueWriter.WriteValue(st, reflect.ValueOf(e))
-			st.Writer.AppendString(",\n")
+			st.WriteBlockItemEnd()
+			st.PopPath()
 		}
 	case interface{ Unwrap() []error }:
 		errs := err.Unwrap()
 		if len(errs) > 0 {
-			st.WriteIndent()
+			st.PushPath(PathElement{Kind: PathElementMethod, Name: "Unwrap"})
+			st.WriteBlockItemStart(false)
 			st.Writer.AppendString("Unwrap(): ")
 			st.KnownType = false // We want to show the type of the unwrapped errors.
 			vw.ValueWriter.WriteValue(st, reflect.ValueOf(errs))
-			st.Writer.AppendString(",\n")
+			st.WriteBlockItemEnd()
+			st.PopPath()
 		}
 	}
 	st.IndentLevel--
-	st.WriteIndent()
+	st.WriteBlockEnd(true)
 	st.Writer.AppendByte('}')
 	return true
 }
@@ -108,15 +113,28 @@ type VerboseError interface {
 }
 
 // WriteVerboseError writes the verbose error message of an error that implements [VerboseError].
+//
+// In compact mode, the message is written as a quoted string.
 func (vw *ErrorWriter) WriteVerboseError(st *State, err error) {
 	v, ok := err.(VerboseError)
 	if !ok {
 		return
 	}
-	st.WriteIndent()
-	st.Writer.AppendString("ErrorVerbose():\n")
+	st.PushPath(PathElement{Kind: PathElementMethod, Name: "ErrorVerbose"})
+	defer st.PopPath()
+	st.WriteBlockItemStart(false)
+	if st.Compact {
+		st.Writer.AppendString("ErrorVerbose(): ")
+		bw := bytesWriterPool.Get()
+		v.ErrorVerbose(bw)
+		st.Writer = strconv.AppendQuote(st.Writer, bw.String())
+		bytesWriterPool.Put(bw)
+		return
+	}
+	st.Writer.AppendString("ErrorVerbose():")
+	st.writeNewLine()
 	st.IndentLevel++
-	iw := indent.NewWriter(&st.Writer, st.IndentString, st.IndentLevel, false)
+	iw := st.newIndentWriter()
 	v.ErrorVerbose(iw)
 	iw.Release()
 	st.Writer.AppendString("\n")
@@ -129,15 +147,28 @@ type StackFramesError interface {
 }
 
 // WriteStackFramesError writes the stack frames of an error that implements [StackFramesError].
+//
+// In compact mode, only the number of frames is written.
 func (vw *ErrorWriter) WriteStackFramesError(st *State, err error) {
 	v, ok := err.(StackFramesError)
 	if !ok {
 		return
 	}
-	st.WriteIndent()
-	st.Writer.AppendString("StackFrames():\n")
+	st.PushPath(PathElement{Kind: PathElementMethod, Name: "StackFrames"})
+	defer st.PopPath()
+	st.WriteBlockItemStart(false)
+	if st.Compact {
+		st.Writer.AppendString("StackFrames(): ")
+		infos{
+			showLen: true,
+			len:     len(v.StackFrames()),
+		}.write(st)
+		return
+	}
+	st.Writer.AppendString("StackFrames():")
+	st.writeNewLine()
 	st.IndentLevel++
-	iw := indent.NewWriter(&st.Writer, st.IndentString, st.IndentLevel, false)
+	iw := st.newIndentWriter()
 	_, _ = runtimeutil.WriteCallersFrames(iw, v.StackFrames())
 	iw.Release()
 	st.IndentLevel--
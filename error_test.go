@@ -45,6 +45,26 @@ func init() {
 				}
 			},
 		},
+		{
+			Name: "Compact",
+			Value: fmt.Errorf("test: %w", &testVerboseError{
+				error: errors.New("error"),
+			}),
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+		},
+		{
+			Name: "CompactStackFrames",
+			Value: &stackFramesError{
+				callers: runtimeutil.GetCallers(0),
+			},
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			IgnoreResult:    true,
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:            "Nil",
 			Value:           (*testError)(nil),
@@ -12,9 +12,10 @@ import (
 
 var messageImplementsCache = reflectutil.NewImplementsCacheFor[protoreflect.ProtoMessage]()
 
-// ConfigureDefault configures [pretty.DefaultWriter] with [ConfigureCommonWriterDefault].
+// ConfigureDefault configures [pretty.DefaultWriter] with [ConfigureCommonWriterDefault], and [pretty.DefaultSelector] with [ConfigureSelector].
 func ConfigureDefault() {
 	ConfigureCommonWriterDefault(pretty.DefaultWriter.Load())
+	ConfigureSelector(pretty.DefaultSelector.Load())
 }
 
 // ConfigureCommonWriterDefault configures a [pretty.CommonWriter] with a default [MessageWriter].
@@ -27,6 +28,37 @@ func ConfigureCommonWriter(vw *pretty.CommonWriter, mw *MessageWriter) {
 	vw.ValueWriters = append(vw.ValueWriters, mw)
 }
 
+// ConfigureSelector configures a [pretty.Selector] with [SelectChildren].
+func ConfigureSelector(s *pretty.Selector) {
+	s.Children = append(s.Children, SelectChildren)
+}
+
+// SelectChildren is a [pretty.SelectChildrenFunc] that handles protobuf messages.
+//
+// The children are the fields of the message, with their protobuf names.
+func SelectChildren(v reflect.Value, yield func(e pretty.PathElement, child reflect.Value) bool) bool {
+	if !messageImplementsCache.ImplementedBy(v.Type()) {
+		return false
+	}
+	pm, ok := itfassert.Assert[protoreflect.ProtoMessage](v)
+	if !ok {
+		return false
+	}
+	m := pm.ProtoReflect()
+	fs := m.Descriptor().Fields()
+	for i := range fs.Len() {
+		fd := fs.Get(i)
+		if fd.ContainingOneof() != nil && !m.Has(fd) {
+			continue
+		}
+		e := pretty.PathElement{Kind: pretty.PathElementField, Name: string(fd.Name())}
+		if !yield(e, reflect.ValueOf(getInterface(m.Get(fd), fd))) {
+			break
+		}
+	}
+	return true
+}
+
 // MessageWriter is a [pretty.ValueWriter] that handles protobuf messages.
 //
 // It should be created with [NewMessageWriter].
@@ -35,6 +67,9 @@ type MessageWriter struct {
 	// ShowFieldsType shows the type of the fields.
 	// Default: true.
 	ShowFieldsType bool
+	// AlignFields pads the field names, so all the values start at the same column.
+	// Default: false.
+	AlignFields bool
 }
 
 // NewMessageWriter creates a new [MessageWriter].
@@ -42,6 +77,7 @@ func NewMessageWriter(vw pretty.ValueWriter) *MessageWriter {
 	return &MessageWriter{
 		ValueWriter:    vw,
 		ShowFieldsType: true,
+		AlignFields:    false,
 	}
 }
 
@@ -64,65 +100,74 @@ func (vw *MessageWriter) writeMessage(st *pretty.State, m protoreflect.Message)
 	fs := m.Descriptor().Fields()
 	l := fs.Len()
 	hasFields := false
+	align := 0
+	if vw.AlignFields {
+		align = st.AlignBlockStart()
+	}
 	st.IndentLevel++
 	for i := range l {
 		fd := fs.Get(i)
 		if fd.ContainingOneof() != nil && !m.Has(fd) {
 			continue
 		}
-		if !hasFields {
-			st.Writer.AppendByte('\n')
-			hasFields = true
-		}
-		st.WriteIndent()
-		st.Writer.AppendString(string(fd.Name()))
+		name := string(fd.Name())
+		st.PushPath(pretty.PathElement{Kind: pretty.PathElementField, Name: name})
+		st.WriteBlockItemStart(!hasFields)
+		hasFields = true
+		keyStart := len(st.Writer)
+		st.Writer.AppendString(name)
 		st.Writer.AppendString(": ")
+		if vw.AlignFields {
+			st.AlignBlockItem(keyStart)
+		}
 		st.KnownType = !vw.ShowFieldsType
-		vw.ValueWriter.WriteValue(st, reflect.ValueOf(vw.getInterface(m.Get(fd), fd)))
-		st.Writer.AppendString(",\n")
+		vw.ValueWriter.WriteValue(st, reflect.ValueOf(getInterface(m.Get(fd), fd)))
+		st.WriteBlockItemEnd()
+		st.PopPath()
 	}
 	st.IndentLevel--
-	if hasFields {
-		st.WriteIndent()
+	if vw.AlignFields {
+		st.AlignBlockEnd(align)
 	}
+	st.WriteBlockEnd(hasFields)
 	st.Writer.AppendByte('}')
 }
 
-func (vw *MessageWriter) getInterface(v protoreflect.Value, fd protoreflect.FieldDescriptor) any {
+func getInterface(v protoreflect.Value, fd protoreflect.FieldDescriptor) any {
 	itf := v.Interface()
 	switch itf := itf.(type) {
 	case protoreflect.Message:
 		return itf.Interface()
 	case protoreflect.List:
-		return vw.getList(itf, fd)
+		return getList(itf, fd)
 	case protoreflect.Map:
-		return vw.getMap(itf, fd)
+		return getMap(itf, fd)
 	case protoreflect.EnumNumber:
-		return vw.getEnum(itf, fd)
+		return getEnum(itf, fd)
 	}
 	return itf
 }
 
-func (vw *MessageWriter) getList(l protoreflect.List, fd protoreflect.FieldDescriptor) any {
+func getList(l protoreflect.List, fd protoreflect.FieldDescriptor) any {
 	// TODO create typed slice
 	res := make([]any, l.Len())
 	for i := range l.Len() {
-		res[i] = vw.getInterface(l.Get(i), fd)
+		res[i] = getInterface(l.Get(i), fd)
 	}
 	return res
 }
 
-func (vw *MessageWriter) getMap(m protoreflect.Map, fd protoreflect.FieldDescriptor) any {
+func getMap(m protoreflect.Map, fd protoreflect.FieldDescriptor) any {
 	// TODO create typed map
 	res := make(map[any]any, m.Len())
 	m.Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
-		res[vw.getInterface(key.Value(), fd.MapKey())] = vw.getInterface(value, fd.MapValue())
+		res[getInterface(key.Value(), fd.MapKey())] = getInterface(value, fd.MapValue())
 		return true
 	})
 	return res
 }
 
-func (vw *MessageWriter) getEnum(e protoreflect.EnumNumber, fd protoreflect.FieldDescriptor) EnumValue {
+func getEnum(e protoreflect.EnumNumber, fd protoreflect.FieldDescriptor) EnumValue {
 	res := EnumValue{
 		Number: int32(e),
 	}
@@ -67,11 +67,28 @@ func init() {
 			},
 			ConfigureWriter: ConfigureCommonWriterDefault,
 		},
+		{
+			Name:  "Compact",
+			Value: wrapperspb.String("test"),
+			ConfigurePrinter: func(p *pretty.Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: ConfigureCommonWriterDefault,
+		},
 		{
 			Name:            "Api",
 			Value:           &apipb.Api{},
 			ConfigureWriter: ConfigureCommonWriterDefault,
 		},
+		{
+			Name:  "AlignFields",
+			Value: &apipb.Api{},
+			ConfigureWriter: func(vw *pretty.CommonWriter) {
+				mw := NewMessageWriter(vw)
+				mw.AlignFields = true
+				ConfigureCommonWriter(vw, mw)
+			},
+		},
 		{
 			Name:  "HideFieldsType",
 			Value: &apipb.Api{},
@@ -95,6 +112,19 @@ func TestSupports(t *testing.T) {
 	assert.Zero(t, vw.Supports(reflect.TypeFor[string]()))
 }
 
+func TestSelect(t *testing.T) {
+	m := &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			"test": structpb.NewStringValue("value"),
+		},
+	}
+	sels, err := pretty.Select(m, `.fields["test"].string_value`)
+	assert.NoError(t, err)
+	assert.SliceLen(t, sels, 1)
+	assert.Equal(t, sels[0].Path.String(), `.fields["test"].string_value`)
+	assert.Equal(t, sels[0].Value.Interface(), any("value"))
+}
+
 func Benchmark(b *testing.B) {
 	prettytest.Benchmark(b)
 }
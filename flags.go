@@ -0,0 +1,168 @@
+package pretty
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/pierrre/go-libs/syncutil"
+)
+
+// flagsNames contains the names of the bits of a flags type.
+//
+// The signed values are stored as their two's complement representation.
+type flagsNames struct {
+	// bits contains the named bits, sorted by value.
+	bits     []flagsBit
+	zeroName string
+	hasZero  bool
+}
+
+type flagsBit struct {
+	mask uint64
+	name string
+}
+
+var (
+	flagsRegistry   syncutil.Map[reflect.Type, *flagsNames]
+	flagsRegistered atomic.Bool
+)
+
+// RegisterFlags registers the names of the bits of a flags type, for [FlagsWriter].
+//
+// A name can be associated to a single bit or to several bits.
+// A name associated to several bits is skipped if all its bits are already written by the previous names.
+// The name associated to 0 is used for the zero value, instead of [FlagsWriter.ZeroName].
+//
+// It replaces the previous registration of the type.
+// It can be called after values of the type were written, because [IntWriter] and [UintWriter] check the registration for each value.
+func RegisterFlags[T integer](names map[T]string) {
+	typ := reflect.TypeFor[T]()
+	fns := &flagsNames{
+		bits: make([]flagsBit, 0, len(names)),
+	}
+	for v, n := range names {
+		if v == 0 {
+			fns.zeroName = n
+			fns.hasZero = true
+			continue
+		}
+		fns.bits = append(fns.bits, flagsBit{
+			mask: truncateFlagsBits(uint64(v), typ.Bits()),
+			name: n,
+		})
+	}
+	slices.SortFunc(fns.bits, func(a, b flagsBit) int {
+		return cmp.Compare(a.mask, b.mask)
+	})
+	flagsRegistry.Store(typ, fns)
+	flagsRegistered.Store(true)
+}
+
+func getFlagsNames(typ reflect.Type) (*flagsNames, bool) {
+	if !flagsRegistered.Load() {
+		return nil, false // Fast path for the types that are not registered.
+	}
+	return flagsRegistry.Load(typ)
+}
+
+// FlagsWriter is a [ValueWriter] that handles the flags types registered with [RegisterFlags].
+//
+// It writes the names of the set bits, sorted by value, followed by the remaining bits in hex, e.g. `Read|Write|0x40`.
+//
+// It is used by [IntWriter] and [UintWriter].
+//
+// It should be created with [NewFlagsWriter].
+type FlagsWriter struct {
+	// ZeroName is the name of the zero value, if it is not registered.
+	// Default: "0".
+	ZeroName string
+	// ShowValue shows the value after the names, e.g. `Read|Write (3)`.
+	// Default: false.
+	ShowValue bool
+}
+
+// NewFlagsWriter creates a new [FlagsWriter] with default values.
+func NewFlagsWriter() *FlagsWriter {
+	return &FlagsWriter{
+		ZeroName:  "0",
+		ShowValue: false,
+	}
+}
+
+// WriteValue implements [ValueWriter].
+func (vw *FlagsWriter) WriteValue(st *State, v reflect.Value) bool {
+	fns, ok := getFlagsNames(v.Type())
+	if !ok {
+		return false
+	}
+	signed := v.CanInt()
+	var value uint64
+	if signed {
+		value = uint64(v.Int())
+	} else {
+		value = v.Uint()
+	}
+	if value == 0 {
+		if fns.hasZero {
+			st.Writer.AppendString(fns.zeroName)
+		} else {
+			st.Writer.AppendString(vw.ZeroName)
+		}
+	} else {
+		writeFlagsBits(st, fns, value, v.Type().Bits())
+	}
+	if vw.ShowValue {
+		st.Writer.AppendString(" (")
+		if signed {
+			st.Writer = strconv.AppendInt(st.Writer, int64(value), 10)
+		} else {
+			st.Writer = strconv.AppendUint(st.Writer, value, 10)
+		}
+		st.Writer.AppendByte(')')
+	}
+	return true
+}
+
+func writeFlagsBits(st *State, fns *flagsNames, value uint64, size int) {
+	value = truncateFlagsBits(value, size)
+	remaining := value
+	first := true
+	for _, b := range fns.bits {
+		if value&b.mask != b.mask || remaining&b.mask == 0 {
+			continue // Not set, or already written by the previous names.
+		}
+		if !first {
+			st.Writer.AppendByte('|')
+		}
+		first = false
+		st.Writer.AppendString(b.name)
+		remaining &^= b.mask
+	}
+	if remaining != 0 {
+		if !first {
+			st.Writer.AppendByte('|')
+		}
+		st.Writer.AppendString("0x")
+		st.Writer = strconv.AppendUint(st.Writer, remaining, 16)
+	}
+}
+
+// truncateFlagsBits keeps the bits of the type size, and removes the sign extension of negative values.
+func truncateFlagsBits(value uint64, size int) uint64 {
+	if size < 64 {
+		value &= 1<<size - 1
+	}
+	return value
+}
+
+// Supports implements [SupportChecker].
+func (vw *FlagsWriter) Supports(typ reflect.Type) ValueWriter {
+	var res ValueWriter
+	if _, ok := getFlagsNames(typ); ok {
+		res = vw
+	}
+	return res
+}
@@ -0,0 +1,115 @@
+package pretty_test
+
+import (
+	"testing"
+
+	"github.com/pierrre/assert"
+	. "github.com/pierrre/pretty"
+	"github.com/pierrre/pretty/internal/prettytest"
+)
+
+func init() {
+	RegisterFlags(map[testFlags]string{
+		testFlagsRead:  "Read",
+		testFlagsWrite: "Write",
+		testFlagsExec:  "Exec",
+	})
+	RegisterFlags(map[testFlagsInt]string{
+		0:     "None",
+		1:     "A",
+		2:     "B",
+		-128:  "Sign",
+		1 | 2: "AB",
+	})
+	prettytest.AddCasesPrefix("Flags", []*prettytest.Case{
+		{
+			Name:  "Default",
+			Value: testFlagsRead | testFlagsWrite | 0x40,
+		},
+		{
+			Name:            "Single",
+			Value:           testFlagsExec,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Zero",
+			Value:           testFlags(0),
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "ZeroName",
+			Value: testFlags(0),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Uint.Flags.ZeroName = "<none>"
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "ZeroRegistered",
+			Value:           testFlagsInt(0),
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Unknown",
+			Value:           testFlags(0x30),
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Signed",
+			Value:           testFlagsInt(-128 | 1 | 2 | 4),
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "ShowValue",
+			Value: testFlagsRead | testFlagsWrite,
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Uint.Flags.ShowValue = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "SupportDisabled",
+			Value: testFlagsRead | testFlagsWrite,
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Support = nil
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Disabled",
+			Value: testFlagsRead | testFlagsWrite,
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Uint.Flags = nil
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "NotRegistered",
+			Value:           uint32(3),
+			IgnoreBenchmark: true,
+		},
+	})
+}
+
+type testFlags uint32
+
+const (
+	testFlagsRead testFlags = 1 << iota
+	testFlagsWrite
+	testFlagsExec
+)
+
+type testFlagsInt int8
+
+type testFlagsLate uint8
+
+func TestRegisterFlagsAfterWrite(t *testing.T) {
+	p := NewPrinter(NewCommonWriter())
+	s := p.String(testFlagsLate(1))
+	assert.Equal(t, s, "[github.com/pierrre/pretty_test.testFlagsLate](uint8) 1")
+	RegisterFlags(map[testFlagsLate]string{
+		1: "A",
+	})
+	s = p.String(testFlagsLate(1))
+	assert.Equal(t, s, "[github.com/pierrre/pretty_test.testFlagsLate](uint8) A")
+}
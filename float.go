@@ -2,7 +2,6 @@ package pretty
 
 import (
 	"reflect"
-	"strconv"
 )
 
 // FloatWriter is a [ValueWriter] that handles float values.
@@ -15,13 +14,18 @@ type FloatWriter struct {
 	// Precision is the precision used to format the float.
 	// Default: -1.
 	Precision int
+	// NumberFormat is the format of the number.
+	// Only GroupSeparator is used.
+	// Default: zero value.
+	NumberFormat NumberFormat
 }
 
 // NewFloatWriter creates a new [FloatWriter] with default values.
 func NewFloatWriter() *FloatWriter {
 	return &FloatWriter{
-		Format:    'g',
-		Precision: -1,
+		Format:       'g',
+		Precision:    -1,
+		NumberFormat: NumberFormat{},
 	}
 }
 
@@ -36,7 +40,7 @@ func (vw *FloatWriter) WriteValue(st *State, v reflect.Value) bool {
 	default:
 		return false
 	}
-	st.Writer = strconv.AppendFloat(st.Writer, v.Float(), vw.Format, vw.Precision, bitSize)
+	st.Writer = vw.NumberFormat.appendFloat(st.Writer, v.Float(), vw.Format, vw.Precision, bitSize)
 	return true
 }
 
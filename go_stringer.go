@@ -13,11 +13,21 @@ var goStringerImplementsCache = reflectutil.NewImplementsCacheFor[fmt.GoStringer
 // GoStringerWriter is a [ValueWriter] that handles [fmt.GoStringer].
 //
 // It should be created with [NewGoStringerWriter].
-type GoStringerWriter struct{}
+type GoStringerWriter struct {
+	// Block writes the strings containing new lines as an indented text block (see [StringWriter.Block]).
+	// Default: false.
+	Block bool
+	// BlockMinLen is the minimum length of the string, in bytes, for Block.
+	// Default: 0.
+	BlockMinLen int
+}
 
 // NewGoStringerWriter creates a new [GoStringerWriter].
 func NewGoStringerWriter() *GoStringerWriter {
-	return &GoStringerWriter{}
+	return &GoStringerWriter{
+		Block:       false,
+		BlockMinLen: 0,
+	}
 }
 
 // WriteValue implements [ValueWriter].
@@ -32,7 +42,11 @@ func (vw *GoStringerWriter) WriteValue(st *State, v reflect.Value) bool {
 	}
 	s := gsr.GoString()
 	writeArrowWrappedString(st, "GoString() ")
-	st.Writer.AppendString(s)
+	if vw.Block && isStringBlock(st, s, vw.BlockMinLen) {
+		writeStringBlock(st, s, 0, "")
+	} else {
+		st.Writer.AppendString(s)
+	}
 	return true
 }
 
@@ -21,6 +21,14 @@ func init() {
 			Name:  "BinaryLittleEndian",
 			Value: binary.LittleEndian,
 		},
+		{
+			Name:  "Block",
+			Value: testGoStringer{},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.GoStringer.ValueWriter.Block = true
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:  "SupportDisabled",
 			Value: binary.LittleEndian,
@@ -38,3 +46,9 @@ func init() {
 		},
 	})
 }
+
+type testGoStringer struct{}
+
+func (testGoStringer) GoString() string {
+	return "testGoStringer{\n\tA: 1,\n}"
+}
@@ -20,7 +20,14 @@ func (i infos) write(st *State) bool {
 	if !i.showLen && !i.showCap && !i.showAddr {
 		return false
 	}
-	st.Writer.AppendByte('(')
+	if st.size.pending && st.size.end == len(st.Writer) {
+		// Merges the infos with the size written by SizeWriter, if nothing was written after it.
+		st.Writer = st.Writer[:len(st.Writer)-2] // Removes ") ".
+		st.Writer.AppendByte(' ')
+		st.size.pending = false
+	} else {
+		st.Writer.AppendByte('(')
+	}
 	wrote := false
 	if i.showLen {
 		st.Writer.AppendString("len=")
@@ -40,7 +47,7 @@ func (i infos) write(st *State) bool {
 			st.Writer.AppendByte(' ')
 		}
 		st.Writer.AppendString("addr=")
-		writeUintptr(st, i.addr)
+		writeAddr(st, i.addr)
 	}
 	st.Writer.AppendByte(')')
 	return true
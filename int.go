@@ -12,12 +12,21 @@ type IntWriter struct {
 	// Base is the base used to format the integer.
 	// Default: 10.
 	Base int
+	// NumberFormat is the format of the number.
+	// Default: zero value.
+	NumberFormat NumberFormat
+	// Flags writes the flags types registered with [RegisterFlags].
+	// The other types are not affected.
+	// Default: [NewFlagsWriter].
+	Flags *FlagsWriter
 }
 
 // NewIntWriter creates a new [IntWriter] with default values.
 func NewIntWriter() *IntWriter {
 	return &IntWriter{
-		Base: 10,
+		Base:         10,
+		NumberFormat: NumberFormat{},
+		Flags:        NewFlagsWriter(),
 	}
 }
 
@@ -28,7 +37,19 @@ func (vw *IntWriter) WriteValue(st *State, v reflect.Value) bool {
 	default:
 		return false
 	}
-	st.Writer = strconv.AppendInt(st.Writer, v.Int(), vw.Base)
+	if vw.Flags != nil && vw.Flags.WriteValue(st, v) {
+		return true
+	}
+	i := v.Int()
+	if vw.NumberFormat == (NumberFormat{}) {
+		st.Writer = strconv.AppendInt(st.Writer, i, vw.Base)
+		return true
+	}
+	abs := uint64(i)
+	if i < 0 {
+		abs = -abs
+	}
+	st.Writer = vw.NumberFormat.appendInteger(st.Writer, i < 0, abs, vw.Base, v.Type().Bits())
 	return true
 }
 
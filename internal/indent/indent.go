@@ -67,6 +67,17 @@ func NewWriter(w io.Writer, str string, level int, indented bool) *Writer {
 	return iw
 }
 
+// NewWriterPrefix creates a new [Writer] that writes the given prefix at the beginning of each line, instead of an indentation string.
+//
+// The prefix must not be modified until the [Writer] is released.
+func NewWriterPrefix(w io.Writer, prefix []byte, indented bool) *Writer {
+	iw := writerPool.Get()
+	iw.writer = w
+	iw.bytes = prefix
+	iw.indented = indented
+	return iw
+}
+
 // Write implements [io.Writer].
 //
 // The returned n is the number of bytes consumed from p (excluding indentation), as required by the [io.Writer] contract.
@@ -83,6 +83,18 @@ func TestWriter(t *testing.T) {
 	}, 0)
 }
 
+func TestWriterPrefix(t *testing.T) {
+	buf := new(bytes.Buffer)
+	iw := NewWriterPrefix(buf, []byte("> "), false)
+	defer iw.Release()
+	for _, v := range testWriterValues {
+		n, err := iw.Write(v.b)
+		assert.NoError(t, err)
+		assert.Equal(t, n, v.expectedN)
+	}
+	assert.Equal(t, buf.String(), "> aabb\n> c\n> c\n> dd") //nolint:dupword // Test data.
+}
+
 var testWriterValues = []struct {
 	b         []byte
 	expectedN int
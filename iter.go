@@ -48,8 +48,13 @@ func (vw *IterWriter) Supports(typ reflect.Type) ValueWriter {
 type IterSeqWriter struct {
 	ValueWriter
 	// MaxLen is the maximum length of the iterator.
+	// If the iterator exceeds this length, it is truncated (see Truncate).
 	// Default: 0 (no limit).
 	MaxLen int
+	// Truncate is the mode used to truncate the iterator.
+	// With [TruncateModeMiddle], the iterator is fully consumed, and the values of the tail are kept in memory.
+	// Default: [TruncateModeHead].
+	Truncate TruncateMode
 }
 
 // NewIterSeqWriter creates a new [IterSeqWriter] with default values.
@@ -57,6 +62,7 @@ func NewIterSeqWriter(vw ValueWriter) *IterSeqWriter {
 	return &IterSeqWriter{
 		ValueWriter: vw,
 		MaxLen:      0,
+		Truncate:    TruncateModeHead,
 	}
 }
 
@@ -76,30 +82,49 @@ func (vw *IterSeqWriter) WriteValue(st *State, v reflect.Value) bool {
 	}
 	st.Writer.AppendByte('{')
 	st.IndentLevel++
+	head, tail, _ := getTruncateLens(vw.MaxLen+1, vw.MaxLen, vw.Truncate) // The length is unknown, so it assumes that it exceeds MaxLen.
+	var tailValues []reflect.Value
+	if tail > 0 {
+		tailValues = make([]reflect.Value, tail)
+	}
 	i := 0
 	v.Seq()(func(v reflect.Value) bool {
-		if i == 0 {
-			st.Writer.AppendByte('\n')
-		}
-		st.WriteIndent()
-		if vw.MaxLen > 0 && i >= vw.MaxLen {
-			writeTruncated(st)
-			st.Writer.AppendByte('\n')
-			return false
+		if vw.MaxLen > 0 && i >= head {
+			if tail == 0 {
+				writeBlockTruncated(st, i == 0)
+				return false
+			}
+			tailValues[(i-head)%tail] = v
+			i++
+			return true
 		}
-		vw.ValueWriter.WriteValue(st, v)
-		st.Writer.AppendString(",\n")
+		vw.writeItem(st, v, i)
 		i++
 		return true
 	})
-	st.IndentLevel--
-	if i != 0 {
-		st.WriteIndent()
+	if tail > 0 && i > head {
+		start := max(head, i-tail)
+		if start > head {
+			writeBlockOmitted(st, head == 0, start-head)
+		}
+		for j := start; j < i; j++ {
+			vw.writeItem(st, tailValues[(j-head)%tail], j)
+		}
 	}
+	st.IndentLevel--
+	st.WriteBlockEnd(i != 0)
 	st.Writer.AppendByte('}')
 	return true
 }
 
+func (vw *IterSeqWriter) writeItem(st *State, v reflect.Value, i int) {
+	st.PushPath(PathElement{Kind: PathElementIndex, Index: i})
+	st.WriteBlockItemStart(i == 0)
+	vw.ValueWriter.WriteValue(st, v)
+	st.WriteBlockItemEnd()
+	st.PopPath()
+}
+
 // Supports implements [SupportChecker].
 func (vw *IterSeqWriter) Supports(typ reflect.Type) ValueWriter {
 	var res ValueWriter
@@ -118,8 +143,13 @@ type IterSeq2Writer struct {
 	// Default: false.
 	ShowKeysInfos bool
 	// MaxLen is the maximum length of the iterator.
+	// If the iterator exceeds this length, it is truncated (see Truncate).
 	// Default: 0 (no limit).
 	MaxLen int
+	// Truncate is the mode used to truncate the iterator.
+	// With [TruncateModeMiddle], the iterator is fully consumed, and the values of the tail are kept in memory.
+	// Default: [TruncateModeHead].
+	Truncate TruncateMode
 }
 
 // NewIterSeq2Writer creates a new [IterSeq2Writer] with default values.
@@ -128,6 +158,7 @@ func NewIterSeq2Writer(vw ValueWriter) *IterSeq2Writer {
 		ValueWriter:   vw,
 		ShowKeysInfos: false,
 		MaxLen:        0,
+		Truncate:      TruncateModeHead,
 	}
 }
 
@@ -147,35 +178,56 @@ func (vw *IterSeq2Writer) WriteValue(st *State, v reflect.Value) bool {
 	}
 	st.Writer.AppendByte('{')
 	st.IndentLevel++
+	head, tail, _ := getTruncateLens(vw.MaxLen+1, vw.MaxLen, vw.Truncate) // The length is unknown, so it assumes that it exceeds MaxLen.
+	var tailKeys, tailValues []reflect.Value
+	if tail > 0 {
+		tailKeys = make([]reflect.Value, tail)
+		tailValues = make([]reflect.Value, tail)
+	}
 	i := 0
 	v.Seq2()(func(k, v reflect.Value) bool {
-		if i == 0 {
-			st.Writer.AppendByte('\n')
-		}
-		st.WriteIndent()
-		if vw.MaxLen > 0 && i >= vw.MaxLen {
-			writeTruncated(st)
-			st.Writer.AppendByte('\n')
-			return false
+		if vw.MaxLen > 0 && i >= head {
+			if tail == 0 {
+				writeBlockTruncated(st, i == 0)
+				return false
+			}
+			tailKeys[(i-head)%tail] = k
+			tailValues[(i-head)%tail] = v
+			i++
+			return true
 		}
-		showInfos := st.ShowInfos
-		st.ShowInfos = vw.ShowKeysInfos
-		vw.ValueWriter.WriteValue(st, k)
-		st.ShowInfos = showInfos
-		st.Writer.AppendString(": ")
-		vw.ValueWriter.WriteValue(st, v)
-		st.Writer.AppendString(",\n")
+		vw.writeItem(st, k, v, i)
 		i++
 		return true
 	})
-	st.IndentLevel--
-	if i != 0 {
-		st.WriteIndent()
+	if tail > 0 && i > head {
+		start := max(head, i-tail)
+		if start > head {
+			writeBlockOmitted(st, head == 0, start-head)
+		}
+		for j := start; j < i; j++ {
+			vw.writeItem(st, tailKeys[(j-head)%tail], tailValues[(j-head)%tail], j)
+		}
 	}
+	st.IndentLevel--
+	st.WriteBlockEnd(i != 0)
 	st.Writer.AppendByte('}')
 	return true
 }
 
+func (vw *IterSeq2Writer) writeItem(st *State, k, v reflect.Value, i int) {
+	st.PushPath(PathElement{Kind: PathElementKey, Key: k})
+	st.WriteBlockItemStart(i == 0)
+	showInfos := st.ShowInfos
+	st.ShowInfos = vw.ShowKeysInfos
+	vw.ValueWriter.WriteValue(st, k)
+	st.ShowInfos = showInfos
+	st.Writer.AppendString(": ")
+	vw.ValueWriter.WriteValue(st, v)
+	st.WriteBlockItemEnd()
+	st.PopPath()
+}
+
 // Supports implements [SupportChecker].
 func (vw *IterSeq2Writer) Supports(typ reflect.Type) ValueWriter {
 	var res ValueWriter
@@ -30,6 +30,23 @@ func init() {
 				vw.Iter.Seq.MaxLen = 2
 			},
 		},
+		{
+			Name:  "TruncateMiddle",
+			Value: slices.Values([]string{"a", "b", "c", "d", "e", "f"}),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Iter.Seq.MaxLen = 3
+				vw.Iter.Seq.Truncate = TruncateModeMiddle
+			},
+		},
+		{
+			Name:  "TruncateMiddleNotExceeded",
+			Value: slices.Values([]string{"a", "b", "c"}),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Iter.Seq.MaxLen = 3
+				vw.Iter.Seq.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name: "Large",
 			Value: func() iter.Seq[int] {
@@ -90,6 +107,14 @@ func init() {
 				vw.Iter.Seq2.MaxLen = 2
 			},
 		},
+		{
+			Name:  "TruncateMiddle",
+			Value: slices.All([]string{"a", "b", "c", "d", "e", "f"}),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Iter.Seq2.MaxLen = 4
+				vw.Iter.Seq2.Truncate = TruncateModeMiddle
+			},
+		},
 		{
 			Name:  "KeysStringShowInfos",
 			Value: maps.All(map[string]int{"a": 1}),
@@ -0,0 +1,219 @@
+package pretty
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"unsafe"
+
+	"github.com/pierrre/go-libs/reflectutil"
+	"github.com/pierrre/go-libs/syncutil"
+)
+
+// JSONWriter is a [ValueWriter] that handles JSON objects and arrays embedded in strings and byte slices (e.g. [json.RawMessage]).
+//
+// The JSON value is written as a nested structure, in the same style as maps and slices, e.g. `=> JSON => {"key": "value"}`.
+// The order of the object keys is preserved.
+//
+// It returns false if the value is not a valid JSON object or array, so the next [ValueWriter] is used.
+//
+// It should be created with [NewJSONWriter].
+type JSONWriter struct {
+	// ShowLen shows the len of the string or byte slice.
+	// Default: true.
+	ShowLen bool
+	// MaxLen is the maximum length of the string or byte slice, in bytes.
+	// If the length exceeds this value, it is not parsed.
+	// Default: 1 MiB.
+	MaxLen int
+}
+
+// NewJSONWriter creates a new [JSONWriter] with default values.
+func NewJSONWriter() *JSONWriter {
+	return &JSONWriter{
+		ShowLen: true,
+		MaxLen:  1 << 20,
+	}
+}
+
+// WriteValue implements [ValueWriter].
+func (vw *JSONWriter) WriteValue(st *State, v reflect.Value) bool {
+	if !isJSONType(v.Type()) {
+		return false
+	}
+	var b []byte
+	if v.Kind() == reflect.String {
+		s := v.String()
+		if !hasJSONBlockPrefix(s) {
+			return false
+		}
+		b = unsafe.Slice(unsafe.StringData(s), len(s)) // The bytes are only read.
+	} else {
+		b = v.Bytes()
+	}
+	if vw.MaxLen > 0 && len(b) > vw.MaxLen {
+		return false
+	}
+	if !hasJSONBlockPrefix(b) {
+		return false
+	}
+	dec := newJSONDecoder(b)
+	defer dec.release()
+	start := len(st.Writer)
+	infos{
+		showLen: vw.ShowLen,
+		len:     len(b),
+	}.writeWithTrailingSpace(st)
+	writeArrowWrappedString(st, "JSON ")
+	if !dec.writeValue(st) || !dec.end() {
+		st.Writer = st.Writer[:start] // Falls back to the next ValueWriter.
+		return false
+	}
+	return true
+}
+
+// Supports implements [SupportChecker].
+func (vw *JSONWriter) Supports(typ reflect.Type) ValueWriter {
+	var res ValueWriter
+	if isJSONType(typ) {
+		res = vw
+	}
+	return res
+}
+
+var (
+	jsonRawMessageType           = reflect.TypeFor[json.RawMessage]()
+	textMarshalerImplementsCache = reflectutil.NewImplementsCacheFor[encoding.TextMarshaler]()
+)
+
+// isJSONType returns true if the values of the type can hold JSON.
+//
+// The strings and byte slices with their own text representation ([fmt.Stringer] or [encoding.TextMarshaler], e.g. [json.Number] or [net.IP]) can't, except [json.RawMessage].
+func isJSONType(typ reflect.Type) bool {
+	if typ == jsonRawMessageType {
+		return true
+	}
+	if typ.Kind() != reflect.String && !isJSONBytesType(typ) {
+		return false
+	}
+	return !stringerImplementsCache.ImplementedBy(typ) && !textMarshalerImplementsCache.ImplementedBy(typ)
+}
+
+func isJSONBytesType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8
+}
+
+// hasJSONBlockPrefix returns true if the first non-whitespace character starts a JSON object or array.
+func hasJSONBlockPrefix[S string | []byte](s S) bool {
+	for i := range len(s) {
+		switch s[i] {
+		case ' ', '\t', '\n', '\r':
+		case '{', '[':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+type jsonDecoder struct {
+	reader  bytes.Reader
+	decoder *json.Decoder
+}
+
+var jsonDecoderPool = syncutil.Pool[*jsonDecoder]{
+	New: func() *jsonDecoder {
+		return new(jsonDecoder)
+	},
+}
+
+func newJSONDecoder(b []byte) *jsonDecoder {
+	dec := jsonDecoderPool.Get()
+	dec.reader.Reset(b)
+	dec.decoder = json.NewDecoder(&dec.reader)
+	dec.decoder.UseNumber()
+	return dec
+}
+
+func (dec *jsonDecoder) release() {
+	dec.reader.Reset(nil)
+	dec.decoder = nil
+	jsonDecoderPool.Put(dec)
+}
+
+// writeValue writes the next JSON value.
+//
+// It returns false if the JSON is invalid.
+func (dec *jsonDecoder) writeValue(st *State) bool {
+	tok, err := dec.decoder.Token()
+	if err != nil {
+		return false
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		return dec.writeBlock(st, tok)
+	case string:
+		st.Writer = strconv.AppendQuote(st.Writer, tok)
+	case json.Number:
+		st.Writer.AppendString(string(tok))
+	case bool:
+		st.Writer = strconv.AppendBool(st.Writer, tok)
+	case nil:
+		st.Writer.AppendString("null")
+	}
+	return true
+}
+
+// end returns true if there is no data after the JSON value.
+func (dec *jsonDecoder) end() bool {
+	_, err := dec.decoder.Token()
+	return errors.Is(err, io.EOF)
+}
+
+// writeBlock writes a JSON object or array, after the opening delimiter.
+func (dec *jsonDecoder) writeBlock(st *State, delim json.Delim) bool {
+	object := delim == '{'
+	st.Writer.AppendByte('{')
+	st.IndentLevel++
+	hasItems := false
+	for i := 0; dec.decoder.More(); i++ {
+		e := PathElement{Kind: PathElementIndex, Index: i}
+		var key string
+		if object {
+			tok, err := dec.decoder.Token()
+			if err != nil {
+				st.IndentLevel--
+				return false
+			}
+			key, _ = tok.(string)
+			e = PathElement{Kind: PathElementKey, Key: reflect.ValueOf(key)}
+		}
+		st.PushPath(e)
+		st.WriteBlockItemStart(!hasItems)
+		hasItems = true
+		if object {
+			st.Writer = strconv.AppendQuote(st.Writer, key)
+			st.Writer.AppendString(": ")
+		}
+		ok := dec.writeValue(st)
+		st.WriteBlockItemEnd()
+		st.PopPath()
+		if !ok {
+			st.IndentLevel--
+			return false
+		}
+	}
+	st.IndentLevel--
+	_, err := dec.decoder.Token() // Closing delimiter.
+	if err != nil {
+		return false
+	}
+	st.WriteBlockEnd(hasItems)
+	st.Writer.AppendByte('}')
+	return true
+}
@@ -0,0 +1,101 @@
+package pretty_test
+
+import (
+	"encoding/json"
+	"strings"
+
+	. "github.com/pierrre/pretty"
+	"github.com/pierrre/pretty/internal/prettytest"
+)
+
+func init() {
+	prettytest.AddCasesPrefix("JSON", []*prettytest.Case{
+		{
+			Name:            "String",
+			Value:           `{"b": "test", "a": [1, 2.5, true, null, {}, []], "c": {"d": "e\n"}}`,
+			ConfigureWriter: configureTestJSON,
+		},
+		{
+			Name: "Bytes",
+			Value: testJSONValue{
+				Payload: []byte(`[{"id": 1}, {"id": 2}]`),
+				Raw:     json.RawMessage(`{"ok": true}`),
+			},
+			ConfigureWriter: configureTestJSON,
+		},
+		{
+			Name:  "Compact",
+			Value: `{"a": [1, 2], "b": {"c": "d"}}`,
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: configureTestJSON,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "PathComment",
+			Value: `{"a": [1, 2]}`,
+			ConfigurePrinter: func(p *Printer) {
+				p.PathAnnotation = PathAnnotationComment
+			},
+			ConfigureWriter: configureTestJSON,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Invalid",
+			Value:           []byte(`{"a": 1`),
+			ConfigureWriter: configureTestJSON,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "TrailingData",
+			Value:           `{"a": 1} {"b": 2}`,
+			ConfigureWriter: configureTestJSON,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Stringer",
+			Value:           testJSONStringer(`{"a": 1}`),
+			ConfigureWriter: configureTestJSON,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "NotObject",
+			Value:           `"test"`,
+			ConfigureWriter: configureTestJSON,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "MaxLen",
+			Value: `[` + strings.Repeat(`1,`, 10) + `1]`,
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestJSON(vw)
+				vw.JSON.MaxLen = 10
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Not",
+			Value: 123,
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.ValueWriters = ValueWriters{NewJSONWriter()}
+			},
+			IgnoreBenchmark: true,
+		},
+	})
+}
+
+type testJSONValue struct {
+	Payload []byte
+	Raw     json.RawMessage
+}
+
+type testJSONStringer string
+
+func (s testJSONStringer) String() string {
+	return "stringer"
+}
+
+func configureTestJSON(vw *CommonWriter) {
+	vw.JSON = NewJSONWriter()
+}
@@ -25,8 +25,15 @@ type MapWriter struct {
 	// Default: false.
 	ShowKeysInfos bool
 	// MaxLen is the maximum length of the map.
+	// If the map exceeds this length, it is truncated (see Truncate).
 	// Default: 0 (no limit).
 	MaxLen int
+	// Truncate is the mode used to truncate the map.
+	// Default: [TruncateModeHead].
+	Truncate TruncateMode
+	// AlignKeys pads the keys, so all the values start at the same column.
+	// Default: false.
+	AlignKeys bool
 }
 
 // NewMapWriter creates a new [MapWriter] with default values.
@@ -38,6 +45,8 @@ func NewMapWriter(vw ValueWriter) *MapWriter {
 		SortKeys:      false,
 		ShowKeysInfos: false,
 		MaxLen:        0,
+		Truncate:      TruncateModeHead,
+		AlignKeys:     false,
 	}
 }
 
@@ -58,7 +67,10 @@ func (vw *MapWriter) WriteValue(st *State, v reflect.Value) bool {
 	}.writeWithTrailingSpace(st)
 	st.Writer.AppendByte('{')
 	if l > 0 {
-		st.Writer.AppendByte('\n')
+		align := 0
+		if vw.AlignKeys {
+			align = st.AlignBlockStart()
+		}
 		st.IndentLevel++
 		if vw.SortKeys {
 			vw.writeSorted(st, v)
@@ -66,7 +78,10 @@ func (vw *MapWriter) WriteValue(st *State, v reflect.Value) bool {
 			vw.writeUnsorted(st, v)
 		}
 		st.IndentLevel--
-		st.WriteIndent()
+		if vw.AlignKeys {
+			st.AlignBlockEnd(align)
+		}
+		st.WriteBlockEnd(true)
 	}
 	st.Writer.AppendByte('}')
 	return true
@@ -76,7 +91,7 @@ func (vw *MapWriter) writeSorted(st *State, v reflect.Value) {
 	es := reflectutil.GetSortedMap(v)
 	defer es.Release()
 	for i, e := range es {
-		ok := vw.writeEntry(st, e.Key, e.Value, i)
+		ok := vw.writeEntry(st, e.Key, e.Value, i, len(es))
 		if !ok {
 			break
 		}
@@ -110,6 +125,7 @@ func getReflectValuePool(typ reflect.Type) *syncutil.Pool[*reflect.Value] {
 
 func (vw *MapWriter) writeUnsortedExported(st *State, v reflect.Value) {
 	iter := v.MapRange()
+	l := v.Len()
 	typ := v.Type()
 	keyPool := getReflectValuePool(typ.Key())
 	valuePool := getReflectValuePool(typ.Elem())
@@ -126,7 +142,7 @@ func (vw *MapWriter) writeUnsortedExported(st *State, v reflect.Value) {
 	for i := 0; iter.Next(); i++ {
 		key.SetIterKey(iter)
 		value.SetIterValue(iter)
-		ok := vw.writeEntry(st, key, value, i)
+		ok := vw.writeEntry(st, key, value, i, l)
 		if !ok {
 			break
 		}
@@ -135,30 +151,41 @@ func (vw *MapWriter) writeUnsortedExported(st *State, v reflect.Value) {
 
 func (vw *MapWriter) writeUnsortedUnexported(st *State, v reflect.Value) {
 	iter := v.MapRange()
+	l := v.Len()
 	for i := 0; iter.Next(); i++ {
 		key := iter.Key()
 		value := iter.Value()
-		ok := vw.writeEntry(st, key, value, i)
+		ok := vw.writeEntry(st, key, value, i, l)
 		if !ok {
 			break
 		}
 	}
 }
 
-func (vw *MapWriter) writeEntry(st *State, key reflect.Value, value reflect.Value, i int) bool {
-	st.WriteIndent()
-	if vw.MaxLen > 0 && i >= vw.MaxLen {
-		writeTruncated(st)
-		st.Writer.AppendByte('\n')
-		return false
+func (vw *MapWriter) writeEntry(st *State, key reflect.Value, value reflect.Value, i int, l int) bool {
+	head, tail, omitted := getTruncateLens(l, vw.MaxLen, vw.Truncate)
+	if omitted > 0 && i >= head {
+		if i == head {
+			writeBlockOmitted(st, i == 0, omitted)
+		}
+		if i < l-tail {
+			return tail > 0 // Skips the omitted entries, or stops if there is no tail.
+		}
 	}
+	st.PushPath(PathElement{Kind: PathElementKey, Key: key})
+	st.WriteBlockItemStart(i == 0)
+	keyStart := len(st.Writer)
 	showInfos := st.ShowInfos
 	st.ShowInfos = vw.ShowKeysInfos
 	vw.ValueWriter.WriteValue(st, key)
 	st.ShowInfos = showInfos
 	st.Writer.AppendString(": ")
+	if vw.AlignKeys {
+		st.AlignBlockItem(keyStart)
+	}
 	vw.ValueWriter.WriteValue(st, value)
-	st.Writer.AppendString(",\n")
+	st.WriteBlockItemEnd()
+	st.PopPath()
 	return true
 }
 
@@ -91,6 +91,26 @@ func init() {
 			},
 			IgnoreBenchmark: true,
 		},
+		{
+			Name:  "SortedExportedTruncateMiddle",
+			Value: map[int]int{1: 2, 3: 4, 5: 6, 7: 8, 9: 10},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Map.SortKeys = true
+				vw.Kind.Map.MaxLen = 2
+				vw.Kind.Map.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "SortedExportedTruncateMiddleHeadOnly",
+			Value: map[int]int{1: 2, 3: 4, 5: 6},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Map.SortKeys = true
+				vw.Kind.Map.MaxLen = 1
+				vw.Kind.Map.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:  "SortedUnexported",
 			Value: prettytest.Unexported(map[int]int{1: 2, 3: 4, 5: 6}),
@@ -120,6 +140,17 @@ func init() {
 				vw.Kind.Map.ShowKeysInfos = true
 			},
 		},
+		{
+			Name: "AlignKeys",
+			Value: map[any]any{
+				"a":                    1,
+				"long key":             map[string]int{"b": 2, "long key": 3},
+				testAlignStruct{ID: 4}: 5,
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Map.AlignKeys = true
+			},
+		},
 		{
 			Name:  "SupportDisabled",
 			Value: map[int]int{1: 2},
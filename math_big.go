@@ -1,6 +1,7 @@
 package pretty
 
 import (
+	"bytes"
 	"reflect"
 
 	"github.com/pierrre/pretty/internal/itfassert"
@@ -59,12 +60,17 @@ type MathBigIntWriter struct {
 	// Base is the base used to format the integer.
 	// Default: 10.
 	Base int
+	// NumberFormat is the format of the number.
+	// ZeroPad is ignored, because the size is not fixed.
+	// Default: zero value.
+	NumberFormat NumberFormat
 }
 
 // NewMathBigIntWriter creates a new [MathBigIntWriter] with default values.
 func NewMathBigIntWriter() *MathBigIntWriter {
 	return &MathBigIntWriter{
-		Base: 10,
+		Base:         10,
+		NumberFormat: NumberFormat{},
 	}
 }
 
@@ -83,10 +89,32 @@ func (vw *MathBigIntWriter) WriteValue(st *State, v reflect.Value) bool {
 	if !ok {
 		return false
 	}
-	st.Writer = i.Append(st.Writer, vw.Base)
+	if vw.NumberFormat == (NumberFormat{}) {
+		st.Writer = i.Append(st.Writer, vw.Base)
+		return true
+	}
+	vw.writeFormatted(st, i)
 	return true
 }
 
+func (vw *MathBigIntWriter) writeFormatted(st *State, i interface {
+	Append(buf []byte, base int) []byte
+}) {
+	bw := bytesWriterPool.Get()
+	defer bytesWriterPool.Put(bw)
+	nf := vw.NumberFormat
+	*bw = i.Append((*bw)[:0], vw.Base)
+	digits, neg := bytes.CutPrefix(*bw, []byte("-"))
+	st.Writer = nf.appendDigits(st.Writer, neg, digits, vw.Base, 0, nf.ShowBasePrefix)
+	if nf.DualBase != 0 && nf.DualBase != vw.Base {
+		*bw = i.Append((*bw)[:0], nf.DualBase)
+		digits, _ = bytes.CutPrefix(*bw, []byte("-"))
+		st.Writer.AppendString(" (")
+		st.Writer = nf.appendDigits(st.Writer, neg, digits, nf.DualBase, 0, true)
+		st.Writer.AppendByte(')')
+	}
+}
+
 // Supports implements [SupportChecker].
 func (vw *MathBigIntWriter) Supports(typ reflect.Type) ValueWriter {
 	var res ValueWriter
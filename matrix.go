@@ -0,0 +1,208 @@
+package pretty
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// MatrixWriter is a [ValueWriter] that writes rectangular 2D slices and arrays of numbers as an aligned grid.
+//
+// E.g. [][]float64 or [3][4]int.
+//
+// The numbers are written on a single line by the [ValueWriter], so it honors [FloatWriter.Format], [FloatWriter.Precision] and [IntWriter.Base].
+// Slices of []byte are not handled.
+//
+// It is not enabled by default in [CommonWriter], see [CommonWriter.Matrix].
+//
+// It should be created with [NewMatrixWriter].
+type MatrixWriter struct {
+	ValueWriter
+	// ShowLen shows the len of the rows and columns.
+	// Default: true.
+	ShowLen bool
+	// ShowIndexes shows the indexes of the rows and columns.
+	// Default: true.
+	ShowIndexes bool
+	// MaxRows is the maximum number of rows.
+	// The middle rows are elided.
+	// Default: 0 (no limit).
+	MaxRows int
+	// MaxColumns is the maximum number of columns.
+	// The middle columns are elided.
+	// Default: 0 (no limit).
+	MaxColumns int
+}
+
+// NewMatrixWriter creates a new [MatrixWriter] with default values.
+func NewMatrixWriter(vw ValueWriter) *MatrixWriter {
+	return &MatrixWriter{
+		ValueWriter: vw,
+		ShowLen:     true,
+		ShowIndexes: true,
+		MaxRows:     0,
+		MaxColumns:  0,
+	}
+}
+
+// WriteValue implements [ValueWriter].
+//
+// It doesn't handle values in compact mode.
+func (vw *MatrixWriter) WriteValue(st *State, v reflect.Value) bool {
+	if st.Compact || !isMatrixType(v.Type()) {
+		return false
+	}
+	rows, columns, ok := getMatrixSize(v)
+	if !ok {
+		return false
+	}
+	if st.ShowInfos && vw.ShowLen {
+		st.Writer.AppendString("(rows=")
+		st.Writer = strconv.AppendInt(st.Writer, int64(rows), 10)
+		st.Writer.AppendString(" cols=")
+		st.Writer = strconv.AppendInt(st.Writer, int64(columns), 10)
+		st.Writer.AppendString(") ")
+	}
+	t := tablePool.Get()
+	defer t.release()
+	t.alignRight = true
+	t.hideHeader = !vw.ShowIndexes
+	rowsHead, rowsTail := getMatrixElision(rows, vw.MaxRows)
+	columnsHead, columnsTail := getMatrixElision(columns, vw.MaxColumns)
+	if vw.ShowIndexes {
+		t.addString("")
+	}
+	rangeMatrixElision(columns, columnsHead, columnsTail, func(c int) {
+		if c < 0 {
+			t.addString("...")
+			return
+		}
+		t.addCell(st, func() {
+			st.Writer = strconv.AppendInt(st.Writer, int64(c), 10)
+		})
+	})
+	t.columns = len(t.ends)
+	rangeMatrixElision(rows, rowsHead, rowsTail, func(r int) {
+		if r < 0 {
+			t.elidedRow = len(t.ends) / t.columns
+			for range t.columns {
+				t.addString("...")
+			}
+			return
+		}
+		vw.addRow(st, t, v.Index(r), r, columns, columnsHead, columnsTail)
+		t.paths = append(t.paths, PathElement{Kind: PathElementIndex, Index: r})
+	})
+	writeTable(st, t, 0)
+	return true
+}
+
+func (vw *MatrixWriter) addRow(st *State, t *table, row reflect.Value, r int, columns, columnsHead, columnsTail int) {
+	showInfos := st.ShowInfos
+	compact := st.Compact
+	st.ShowInfos = false
+	st.Compact = true
+	defer func() {
+		st.ShowInfos = showInfos
+		st.Compact = compact
+	}()
+	if vw.ShowIndexes {
+		t.addCell(st, func() {
+			st.Writer = strconv.AppendInt(st.Writer, int64(r), 10)
+		})
+	}
+	rangeMatrixElision(columns, columnsHead, columnsTail, func(c int) {
+		if c < 0 {
+			t.addString("...")
+			return
+		}
+		t.addCell(st, func() {
+			st.KnownType = true
+			vw.ValueWriter.WriteValue(st, row.Index(c))
+		})
+	})
+}
+
+// Supports implements [SupportChecker].
+func (vw *MatrixWriter) Supports(typ reflect.Type) ValueWriter {
+	var res ValueWriter
+	if isMatrixType(typ) {
+		res = vw
+	}
+	return res
+}
+
+func isMatrixType(typ reflect.Type) bool {
+	if !isMatrixListKind(typ.Kind()) {
+		return false
+	}
+	rowTyp := typ.Elem()
+	if !isMatrixListKind(rowTyp.Kind()) {
+		return false
+	}
+	elemKind := rowTyp.Elem().Kind()
+	if rowTyp.Kind() == reflect.Slice && elemKind == reflect.Uint8 {
+		return false // []byte are written by BytesHexDumpWriter.
+	}
+	switch elemKind { //nolint:exhaustive // Only handles numbers.
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		return true
+	}
+	return false
+}
+
+func isMatrixListKind(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// getMatrixSize returns the size of the matrix.
+// It returns false if the matrix is nil, empty or not rectangular.
+func getMatrixSize(v reflect.Value) (rows int, columns int, ok bool) {
+	rows = v.Len()
+	if rows == 0 {
+		return 0, 0, false
+	}
+	for r := range rows {
+		row := v.Index(r)
+		if row.Kind() == reflect.Slice && row.IsNil() {
+			return 0, 0, false
+		}
+		if r == 0 {
+			columns = row.Len()
+		} else if row.Len() != columns {
+			return 0, 0, false
+		}
+	}
+	if columns == 0 {
+		return 0, 0, false
+	}
+	return rows, columns, true
+}
+
+// getMatrixElision returns the number of items shown at the head and tail.
+// If there is no elision, tail is 0 and head is the length.
+func getMatrixElision(l int, maxLen int) (head int, tail int) {
+	if maxLen <= 0 || l <= maxLen {
+		return l, 0
+	}
+	head = (maxLen + 1) / 2
+	tail = maxLen - head
+	return head, tail
+}
+
+// rangeMatrixElision calls f with the head and tail indexes.
+// The elided items are represented by a single call with -1.
+func rangeMatrixElision(l int, head int, tail int, f func(i int)) {
+	for i := range head {
+		f(i)
+	}
+	if head == l {
+		return
+	}
+	f(-1)
+	for i := l - tail; i < l; i++ {
+		f(i)
+	}
+}
@@ -0,0 +1,148 @@
+package pretty_test
+
+import (
+	. "github.com/pierrre/pretty"
+	"github.com/pierrre/pretty/internal/prettytest"
+)
+
+func init() {
+	prettytest.AddCasesPrefix("Matrix", []*prettytest.Case{
+		{
+			Name: "Default",
+			Value: [][]float64{
+				{1, 2.5, -3},
+				{4, 5, 6},
+				{7.125, 8, 9},
+			},
+			ConfigureWriter: configureTestMatrix,
+		},
+		{
+			Name: "Array",
+			Value: [2][3]int{
+				{1, 20, 300},
+				{-4000, 5, 6},
+			},
+			ConfigureWriter: configureTestMatrix,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "Complex",
+			Value: [][]complex128{
+				{1 + 2i, 3},
+				{4i, 5 - 6i},
+			},
+			ConfigureWriter: configureTestMatrix,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "FloatFormat",
+			Value: [][]float64{
+				{1, 2.5},
+				{3.14159, 4},
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestMatrix(vw)
+				vw.Kind.Float.Format = 'f'
+				vw.Kind.Float.Precision = 2
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "IntBase",
+			Value: [][]uint16{
+				{1, 255},
+				{4096, 65535},
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestMatrix(vw)
+				vw.Kind.Uint.Base = 16
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Elision",
+			Value: newTestMatrix(10, 10),
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestMatrix(vw)
+				vw.Matrix.MaxRows = 4
+				vw.Matrix.MaxColumns = 5
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "HideIndexes",
+			Value: newTestMatrix(3, 3),
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestMatrix(vw)
+				vw.Matrix.ShowIndexes = false
+				vw.Matrix.MaxColumns = 2
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "PathComment",
+			Value: newTestMatrix(2, 2),
+			ConfigurePrinter: func(p *Printer) {
+				p.PathAnnotation = PathAnnotationComment
+			},
+			ConfigureWriter: configureTestMatrix,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Compact",
+			Value: newTestMatrix(2, 2),
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: configureTestMatrix,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "NotRectangular",
+			Value: [][]int{
+				{1, 2},
+				{3},
+			},
+			ConfigureWriter: configureTestMatrix,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "NotEmpty",
+			Value:           [][]int{},
+			ConfigureWriter: configureTestMatrix,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "NotBytes",
+			Value: [][]byte{
+				[]byte("ab"),
+				[]byte("cd"),
+			},
+			ConfigureWriter: configureTestMatrix,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Not",
+			Value: []int{1, 2, 3},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.ValueWriters = ValueWriters{NewMatrixWriter(vw)}
+			},
+			IgnoreBenchmark: true,
+		},
+	})
+}
+
+func newTestMatrix(rows, columns int) [][]int {
+	m := make([][]int, rows)
+	for r := range m {
+		m[r] = make([]int, columns)
+		for c := range m[r] {
+			m[r][c] = r*columns + c
+		}
+	}
+	return m
+}
+
+func configureTestMatrix(vw *CommonWriter) {
+	vw.Matrix = NewMatrixWriter(vw)
+}
@@ -2,16 +2,34 @@ package pretty
 
 import (
 	"reflect"
+	"strconv"
+
+	"github.com/pierrre/go-libs/reflectutil"
+	"github.com/pierrre/pretty/internal/itfassert"
 )
 
 // MaxDepthWriter is a [ValueWriter] that limits the depth.
 //
+// When the limit is reached, it writes a summary of the value, e.g. "<max depth: []int, len=3>".
+//
 // It should be created with [NewMaxDepthWriter].
 type MaxDepthWriter struct {
 	ValueWriter
 	// Max is the maximum depth.
 	// Default: 0 (no limit).
 	Max int
+	// MaxByType is the maximum depth below the values of a type, relative to their depth.
+	// E.g. 1 writes the value, and summarizes its children.
+	// The type can be an interface type, which matches the values stored in it (e.g. a struct field).
+	// Default: nil.
+	MaxByType map[reflect.Type]int
+	// MaxByPath is the maximum depth below the values whose [Path] matches a pattern, relative to their depth.
+	// In patterns, "*" matches any sequence of characters, e.g. "*.Logger" or ".Items[*].Parent".
+	// Default: nil.
+	MaxByPath map[string]int
+	// ShowSummary shows a summary of the value: the type, and the len of collections, the number of fields of structs, or the message of errors.
+	// Default: true.
+	ShowSummary bool
 }
 
 // NewMaxDepthWriter creates a new [MaxDepthWriter].
@@ -19,28 +37,157 @@ func NewMaxDepthWriter(vw ValueWriter) *MaxDepthWriter {
 	return &MaxDepthWriter{
 		ValueWriter: vw,
 		Max:         0,
+		MaxByType:   nil,
+		MaxByPath:   nil,
+		ShowSummary: true,
 	}
 }
 
 // WriteValue implements [ValueWriter].
 func (vw *MaxDepthWriter) WriteValue(st *State, v reflect.Value) bool {
-	maxReached := vw.checkMaxDepth(st)
+	maxReached, previousMax := vw.checkMaxDepth(st, v, nil)
+	defer vw.postMaxDepth(st, previousMax)
 	if maxReached {
 		return true
 	}
-	defer vw.postMaxDepth(st)
 	return vw.ValueWriter.WriteValue(st, v)
 }
 
-func (vw *MaxDepthWriter) checkMaxDepth(st *State) (maxReached bool) {
-	if vw.Max > 0 && st.Depth >= vw.Max {
-		st.Writer.AppendString("<max depth>")
-		maxReached = true
+// checkMaxDepth checks the max depth of the value.
+//
+// The interface type is the type of the interface containing the value, if it was unwrapped, or nil.
+func (vw *MaxDepthWriter) checkMaxDepth(st *State, v reflect.Value, itfType reflect.Type) (maxReached bool, previousMax int) {
+	maxReached, previousMax = vw.enterMaxDepth(st, v, itfType)
+	if maxReached {
+		dropReferenceLabel(st, st.Depth-1)
+		vw.writeSummary(st, v)
 	}
+	return maxReached, previousMax
+}
+
+// enterMaxDepth is the same as [MaxDepthWriter.checkMaxDepth], but it doesn't write the summary.
+//
+// It is used by [Printer.Stats].
+func (vw *MaxDepthWriter) enterMaxDepth(st *State, v reflect.Value, itfType reflect.Type) (maxReached bool, previousMax int) {
+	previousMax = st.maxDepth
+	maxDepth := vw.getMaxDepth(st, v, itfType)
+	maxReached = maxDepth > 0 && st.Depth >= maxDepth
+	st.maxDepth = maxDepth
 	st.Depth++
-	return maxReached
+	return maxReached, previousMax
 }
 
-func (vw *MaxDepthWriter) postMaxDepth(st *State) {
+func (vw *MaxDepthWriter) postMaxDepth(st *State, previousMax int) {
 	st.Depth--
+	st.maxDepth = previousMax
+}
+
+// getMaxDepth returns the absolute maximum depth for the value, or 0 if there is no limit.
+func (vw *MaxDepthWriter) getMaxDepth(st *State, v reflect.Value, itfType reflect.Type) int {
+	maxDepth := st.maxDepth
+	if vw.Max > 0 {
+		maxDepth = minMaxDepth(maxDepth, vw.Max)
+	}
+	if len(vw.MaxByType) != 0 {
+		if v.IsValid() {
+			n, ok := vw.MaxByType[v.Type()]
+			if ok {
+				maxDepth = minMaxDepth(maxDepth, st.Depth+n)
+			}
+		}
+		if itfType != nil {
+			n, ok := vw.MaxByType[itfType]
+			if ok {
+				maxDepth = minMaxDepth(maxDepth, st.Depth+n)
+			}
+		}
+	}
+	if len(vw.MaxByPath) != 0 {
+		st.pathBuffer = st.Path.Append(st.pathBuffer[:0])
+		for pattern, n := range vw.MaxByPath {
+			if matchPathPattern(pattern, st.pathBuffer) {
+				maxDepth = minMaxDepth(maxDepth, st.Depth+n)
+			}
+		}
+	}
+	return maxDepth
+}
+
+func minMaxDepth(a, b int) int {
+	if a <= 0 {
+		return b
+	}
+	return min(a, b)
+}
+
+func (vw *MaxDepthWriter) writeSummary(st *State, v reflect.Value) {
+	st.Writer.AppendString("<max depth")
+	if vw.ShowSummary && v.IsValid() {
+		st.Writer.AppendString(": ")
+		writeMaxDepthSummary(st, v)
+	}
+	st.Writer.AppendByte('>')
+}
+
+func writeMaxDepthSummary(st *State, v reflect.Value) {
+	typ := v.Type()
+	st.Writer.AppendString(reflectutil.TypeFullName(typ))
+	kind := v.Kind()
+	switch kind { //nolint:exhaustive // Only handles nillable kinds.
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		if v.IsNil() {
+			st.Writer.AppendString(", nil")
+			return
+		}
+	}
+	if errorImplementsCache.ImplementedBy(typ) {
+		err, ok := itfassert.Assert[error](v)
+		if ok {
+			st.Writer.AppendString(", error=")
+			st.Writer = strconv.AppendQuote(st.Writer, err.Error())
+			return
+		}
+	}
+	if kind == reflect.Pointer {
+		v = v.Elem()
+		kind = v.Kind()
+	}
+	switch kind { //nolint:exhaustive // Only handles kinds with a size.
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan, reflect.String:
+		st.Writer.AppendString(", len=")
+		st.Writer = strconv.AppendInt(st.Writer, int64(v.Len()), 10)
+	case reflect.Struct:
+		st.Writer.AppendString(", fields=")
+		st.Writer = strconv.AppendInt(st.Writer, int64(v.NumField()), 10)
+	}
+}
+
+// matchPathPattern returns true if the path matches the pattern.
+// In the pattern, "*" matches any sequence of characters.
+func matchPathPattern(pattern string, path []byte) bool {
+	px, nx := 0, 0
+	nextPx, nextNx := -1, -1
+	for px < len(pattern) || nx < len(path) {
+		if px < len(pattern) {
+			c := pattern[px]
+			if c == '*' {
+				nextPx = px
+				nextNx = nx + 1
+				px++
+				continue
+			}
+			if nx < len(path) && path[nx] == c {
+				px++
+				nx++
+				continue
+			}
+		}
+		if nextNx > 0 && nextNx <= len(path) {
+			px = nextPx
+			nx = nextNx
+			continue
+		}
+		return false
+	}
+	return true
 }
@@ -1,6 +1,9 @@
 package pretty_test
 
 import (
+	"errors"
+	"reflect"
+
 	. "github.com/pierrre/pretty"
 	"github.com/pierrre/pretty/internal/prettytest"
 )
@@ -20,6 +23,56 @@ func init() {
 				vw.MaxDepth.Max = 2
 			},
 		},
+		{
+			Name:  "Summary",
+			Value: newTestMaxDepthValue(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.MaxDepth.Max = 1
+			},
+		},
+		{
+			Name:  "HideSummary",
+			Value: newTestMaxDepthValue(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.MaxDepth.Max = 1
+				vw.MaxDepth.ShowSummary = false
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "MaxByType",
+			Value: newTestMaxDepthValue(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.MaxDepth.MaxByType = map[reflect.Type]int{
+					reflect.TypeFor[*testMaxDepthLogger](): 1,
+				}
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "MaxByTypeInterface",
+			Value: testMaxDepthInterfaceValue{
+				Logger: newTestMaxDepthValue().Logger,
+				Text:   "test",
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.MaxDepth.MaxByType = map[reflect.Type]int{
+					reflect.TypeFor[testMaxDepthLoggerInterface](): 1,
+				}
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "MaxByPath",
+			Value: newTestMaxDepthValue(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.MaxDepth.MaxByPath = map[string]int{
+					".Items*":   0,
+					"*.Options": 1,
+				}
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:  "Writer",
 			Value: 123,
@@ -38,3 +91,47 @@ func init() {
 		},
 	})
 }
+
+type testMaxDepthValue struct {
+	Items   []int
+	Names   map[string]int
+	Logger  *testMaxDepthLogger
+	Nil     *testMaxDepthLogger
+	Err     error
+	Text    string
+	Options struct {
+		Nested struct {
+			Value int
+		}
+	}
+}
+
+type testMaxDepthLogger struct {
+	Level  int
+	Output struct {
+		Name string
+	}
+}
+
+func (l *testMaxDepthLogger) Log() {}
+
+type testMaxDepthLoggerInterface interface {
+	Log()
+}
+
+type testMaxDepthInterfaceValue struct {
+	Logger testMaxDepthLoggerInterface
+	Text   string
+}
+
+func newTestMaxDepthValue() testMaxDepthValue {
+	v := testMaxDepthValue{
+		Items:  []int{1, 2, 3},
+		Names:  map[string]int{"a": 1},
+		Logger: &testMaxDepthLogger{Level: 1},
+		Err:    errors.New("error"),
+		Text:   "test",
+	}
+	v.Logger.Output.Name = "stdout"
+	return v
+}
@@ -0,0 +1,124 @@
+package pretty
+
+import (
+	"strconv"
+)
+
+// NumberFormat contains the options used to format numbers.
+//
+// It is used by [IntWriter], [UintWriter], [UintptrWriter], [FloatWriter], [ComplexWriter] and [MathBigIntWriter].
+// [FloatWriter] and [ComplexWriter] only use GroupSeparator.
+//
+// It can be set globally with [CommonWriter.SetNumberFormat], or for a specific type with [CommonWriter.ByType].
+//
+// The zero value doesn't change the format.
+type NumberFormat struct {
+	// GroupSeparator is inserted between the groups of digits, e.g. "_" or ",".
+	// The groups contain 3 digits in base 10, and 4 digits in the other bases.
+	// Default: "" (no grouping).
+	GroupSeparator string
+	// ShowBasePrefix writes the prefix of the base: "0b" (2), "0o" (8) or "0x" (16).
+	// Default: false.
+	ShowBasePrefix bool
+	// ZeroPad pads the digits with zeros to the bit size of the type, in base 2 and 16.
+	// Default: false.
+	ZeroPad bool
+	// DualBase writes the value again in another base, with its prefix, e.g. `255 (0xff)`.
+	// It is ignored if it is 0 or the same as the base.
+	// Default: 0.
+	DualBase int
+}
+
+// appendInteger appends an integer.
+//
+// The bit size is used by ZeroPad.
+func (nf NumberFormat) appendInteger(dst []byte, neg bool, abs uint64, base int, bitSize int) []byte {
+	var buf [64]byte
+	dst = nf.appendDigits(dst, neg, strconv.AppendUint(buf[:0], abs, base), base, bitSize, nf.ShowBasePrefix)
+	if nf.DualBase != 0 && nf.DualBase != base {
+		dst = append(dst, " ("...)
+		dst = nf.appendDigits(dst, neg, strconv.AppendUint(buf[:0], abs, nf.DualBase), nf.DualBase, bitSize, true)
+		dst = append(dst, ')')
+	}
+	return dst
+}
+
+func (nf NumberFormat) appendDigits(dst []byte, neg bool, digits []byte, base int, bitSize int, showPrefix bool) []byte {
+	if neg {
+		dst = append(dst, '-')
+	}
+	if showPrefix {
+		dst = appendBasePrefix(dst, base)
+	}
+	if nf.ZeroPad && bitSize > 0 {
+		var digitBits int
+		switch base {
+		case 2:
+			digitBits = 1
+		case 16:
+			digitBits = 4
+		}
+		if digitBits > 0 && bitSize/digitBits > len(digits) {
+			pad := bitSize/digitBits - len(digits)
+			var buf [64]byte // The padded digits are grouped.
+			padded := buf[:0]
+			for range pad {
+				padded = append(padded, '0')
+			}
+			digits = append(padded, digits...)
+		}
+	}
+	return nf.appendGroupedDigits(dst, digits, base)
+}
+
+func (nf NumberFormat) appendGroupedDigits(dst []byte, digits []byte, base int) []byte {
+	if nf.GroupSeparator == "" {
+		return append(dst, digits...)
+	}
+	groupSize := 4
+	if base == 10 {
+		groupSize = 3
+	}
+	first := len(digits) % groupSize
+	if first == 0 {
+		first = groupSize
+	}
+	dst = append(dst, digits[:min(first, len(digits))]...)
+	for i := first; i < len(digits); i += groupSize {
+		dst = append(dst, nf.GroupSeparator...)
+		dst = append(dst, digits[i:i+groupSize]...)
+	}
+	return dst
+}
+
+func appendBasePrefix(dst []byte, base int) []byte {
+	switch base {
+	case 2:
+		dst = append(dst, "0b"...)
+	case 8:
+		dst = append(dst, "0o"...)
+	case 16:
+		dst = append(dst, "0x"...)
+	}
+	return dst
+}
+
+// appendFloat appends a float, and groups the digits of the integer part.
+func (nf NumberFormat) appendFloat(dst []byte, f float64, fmt byte, prec int, bitSize int) []byte {
+	if nf.GroupSeparator == "" || fmt == 'x' || fmt == 'X' || fmt == 'b' {
+		return strconv.AppendFloat(dst, f, fmt, prec, bitSize)
+	}
+	var buf [64]byte
+	b := strconv.AppendFloat(buf[:0], f, fmt, prec, bitSize)
+	start := 0
+	if len(b) > 0 && (b[0] == '-' || b[0] == '+') {
+		start = 1
+	}
+	end := start
+	for end < len(b) && b[end] >= '0' && b[end] <= '9' {
+		end++
+	}
+	dst = append(dst, b[:start]...)
+	dst = nf.appendGroupedDigits(dst, b[start:end], 10)
+	return append(dst, b[end:]...)
+}
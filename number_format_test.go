@@ -0,0 +1,152 @@
+package pretty_test
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+
+	. "github.com/pierrre/pretty"
+	"github.com/pierrre/pretty/internal/prettytest"
+)
+
+func init() {
+	prettytest.AddCasesPrefix("NumberFormat", []*prettytest.Case{
+		{
+			Name:  "IntGroup",
+			Value: []int{1234567890, -1234567, 123, 0, math.MinInt64},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Int.NumberFormat.GroupSeparator = "_"
+			},
+		},
+		{
+			Name:  "IntHex",
+			Value: []int16{255, -1},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Int.Base = 16
+				vw.Kind.Int.NumberFormat = NumberFormat{
+					ShowBasePrefix: true,
+					ZeroPad:        true,
+				}
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "UintBinary",
+			Value: uint16(0xa5),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Uint.Base = 2
+				vw.Kind.Uint.NumberFormat = NumberFormat{
+					GroupSeparator: "_",
+					ShowBasePrefix: true,
+					ZeroPad:        true,
+				}
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "UintOctal",
+			Value: uint(8),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Uint.Base = 8
+				vw.Kind.Uint.NumberFormat.ShowBasePrefix = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "DualBase",
+			Value: []uint16{255, 0},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Uint.NumberFormat = NumberFormat{
+					ZeroPad:  true,
+					DualBase: 16,
+				}
+			},
+		},
+		{
+			Name:  "DualBaseSame",
+			Value: 255,
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Int.NumberFormat.DualBase = 10
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Uintptr",
+			Value: uintptr(0xc000012345),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Uintptr.NumberFormat = NumberFormat{
+					GroupSeparator: "_",
+					ZeroPad:        true,
+				}
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Float",
+			Value: []float64{1234567.125, -1234.5, 1e21, 0.5},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Float.NumberFormat.GroupSeparator = ","
+			},
+		},
+		{
+			Name:  "FloatFormat",
+			Value: 1234567.125,
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Float.Format = 'f'
+				vw.Kind.Float.Precision = 2
+				vw.Kind.Float.NumberFormat.GroupSeparator = ","
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Complex",
+			Value: complex(1234567, -7654321),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Complex.Format = 'f'
+				vw.Kind.Complex.Precision = 1
+				vw.Kind.Complex.NumberFormat.GroupSeparator = ","
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "MathBigInt",
+			Value: new(big.Int).Neg(new(big.Int).Exp(big.NewInt(10), big.NewInt(20), nil)),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.MathBig.Int.NumberFormat = NumberFormat{
+					GroupSeparator: "_",
+					DualBase:       16,
+				}
+			},
+		},
+		{
+			Name:  "Global",
+			Value: testNumberFormat{Int: 1234567, Uint: 255, Float: 12345.5},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.SetNumberFormat(NumberFormat{
+					GroupSeparator: "_",
+					DualBase:       16,
+				})
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "ByType",
+			Value: testNumberFormat{Int: 1234567, Port: 8080},
+			ConfigureWriter: func(vw *CommonWriter) {
+				w := NewIntWriter()
+				w.NumberFormat.DualBase = 16
+				vw.ByType[reflect.TypeFor[testNumberFormatPort]()] = w
+			},
+			IgnoreBenchmark: true,
+		},
+	})
+}
+
+type testNumberFormat struct {
+	Int   int
+	Uint  uint
+	Float float64
+	Port  testNumberFormatPort
+}
+
+type testNumberFormatPort int
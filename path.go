@@ -0,0 +1,110 @@
+package pretty
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Path represents the path of a value from the root value, e.g. ".Body.Items[17].Price".
+//
+// It is maintained in [State.Path] during the traversal.
+type Path []PathElement
+
+// Append appends the string representation of the [Path] to a []byte and returns the result.
+func (p Path) Append(dst []byte) []byte {
+	for _, e := range p {
+		dst = e.Append(dst)
+	}
+	return dst
+}
+
+// String returns the string representation of the [Path].
+func (p Path) String() string {
+	return string(p.Append(nil))
+}
+
+// PathElement represents an element of a [Path].
+type PathElement struct {
+	Kind PathElementKind
+	// Name is the name of the field or method.
+	Name string
+	// Index is the index in a slice, array or iterator.
+	Index int
+	// Key is the key in a map or iterator.
+	Key reflect.Value
+}
+
+// PathElementKind represents the kind of a [PathElement].
+type PathElementKind int
+
+// PathElementKind values.
+const (
+	// PathElementField is a struct field, written as ".Name".
+	PathElementField PathElementKind = iota
+	// PathElementMethod is a method call, written as ".Name()".
+	PathElementMethod
+	// PathElementIndex is an index, written as "[Index]".
+	PathElementIndex
+	// PathElementKey is a key, written as "[Key]".
+	PathElementKey
+)
+
+// Append appends the string representation of the [PathElement] to a []byte and returns the result.
+func (e PathElement) Append(dst []byte) []byte {
+	switch e.Kind {
+	case PathElementField:
+		dst = append(dst, '.')
+		dst = append(dst, e.Name...)
+	case PathElementMethod:
+		dst = append(dst, '.')
+		dst = append(dst, e.Name...)
+		dst = append(dst, "()"...)
+	case PathElementIndex:
+		dst = append(dst, '[')
+		dst = strconv.AppendInt(dst, int64(e.Index), 10)
+		dst = append(dst, ']')
+	case PathElementKey:
+		dst = append(dst, '[')
+		dst = appendPathKey(dst, e.Key)
+		dst = append(dst, ']')
+	}
+	return dst
+}
+
+func appendPathKey(dst []byte, v reflect.Value) []byte {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() { //nolint:exhaustive // Other kinds are handled by the default case.
+	case reflect.Invalid, reflect.Interface:
+		return append(dst, "nil"...)
+	case reflect.String:
+		return strconv.AppendQuote(dst, v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(dst, v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(dst, v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(dst, v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.Bool:
+		return strconv.AppendBool(dst, v.Bool())
+	}
+	if v.CanInterface() {
+		return fmt.Appendf(dst, "%#v", v.Interface())
+	}
+	return append(dst, '?')
+}
+
+// PathAnnotation represents how lines are annotated with the [Path] of their value.
+type PathAnnotation int
+
+// PathAnnotation values.
+const (
+	// PathAnnotationNone doesn't annotate lines.
+	PathAnnotationNone PathAnnotation = iota
+	// PathAnnotationComment appends the path as a trailing comment, e.g. "Price: 123, // .Body.Items[17].Price".
+	PathAnnotationComment
+	// PathAnnotationGutter prefixes the lines with the path in a gutter.
+	PathAnnotationGutter
+)
@@ -0,0 +1,90 @@
+package pretty_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/pierrre/assert"
+	. "github.com/pierrre/pretty"
+	"github.com/pierrre/pretty/internal/prettytest"
+)
+
+func init() {
+	prettytest.AddCasesPrefix("Path", []*prettytest.Case{
+		{
+			Name:  "Comment",
+			Value: newTestPathValue(),
+			ConfigurePrinter: func(p *Printer) {
+				p.PathAnnotation = PathAnnotationComment
+			},
+		},
+		{
+			Name:  "Gutter",
+			Value: newTestPathValue(),
+			ConfigurePrinter: func(p *Printer) {
+				p.PathAnnotation = PathAnnotationGutter
+			},
+		},
+		{
+			Name:  "GutterBytes",
+			Value: []byte("test"),
+			ConfigurePrinter: func(p *Printer) {
+				p.PathAnnotation = PathAnnotationGutter
+				p.PathGutterWidth = 0
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Compact",
+			Value: newTestPathValue(),
+			ConfigurePrinter: func(p *Printer) {
+				p.PathAnnotation = PathAnnotationComment
+				p.Compact = true
+			},
+			IgnoreBenchmark: true,
+		},
+	})
+}
+
+type testPathValue struct {
+	Body testPathBody
+	Err  error
+}
+
+type testPathBody struct {
+	Items  []testPathItem
+	Labels map[string]string
+}
+
+type testPathItem struct {
+	Price int
+}
+
+func newTestPathValue() testPathValue {
+	return testPathValue{
+		Body: testPathBody{
+			Items: []testPathItem{
+				{Price: 1},
+				{Price: 2},
+			},
+			Labels: map[string]string{
+				"foo": "bar",
+			},
+		},
+		Err: errors.New("error"),
+	}
+}
+
+func TestPathString(t *testing.T) {
+	p := Path{
+		{Kind: PathElementField, Name: "Body"},
+		{Kind: PathElementIndex, Index: 17},
+		{Kind: PathElementKey, Key: reflect.ValueOf("key")},
+		{Kind: PathElementKey, Key: reflect.ValueOf(123)},
+		{Kind: PathElementKey, Key: reflect.ValueOf(testPathItem{Price: 1})},
+		{Kind: PathElementKey, Key: reflect.ValueOf(new(any)).Elem()},
+		{Kind: PathElementMethod, Name: "Unwrap"},
+	}
+	assert.Equal(t, p.String(), `.Body[17]["key"][123][pretty_test.testPathItem{Price:1}][nil].Unwrap()`)
+}
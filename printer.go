@@ -47,13 +47,37 @@ type Printer struct {
 	// Indent is the string used to indent.
 	// Default: "\t".
 	Indent string
+	// Compact writes the value on a single line.
+	// Blocks are written with ", " separators, and multi-line content is summarized.
+	// Default: false.
+	Compact bool
+	// PathAnnotation annotates each line with the [Path] of its value.
+	// It is ignored in compact mode.
+	// Default: [PathAnnotationNone].
+	PathAnnotation PathAnnotation
+	// PathGutterWidth is the minimum width of the gutter, with [PathAnnotationGutter].
+	// Default: 32.
+	PathGutterWidth int
+	// SymbolicAddr replaces the addresses with sequential tokens "addr#1", "addr#2", etc.
+	// Equal addresses have the same token, so aliasing is visible.
+	// The tokens are assigned in the writing order for each call, so the result is deterministic.
+	// Default: false.
+	SymbolicAddr bool
+	// Selector is the [Selector] used by [Printer.StringPath].
+	// Default: nil ([DefaultSelector]).
+	Selector *Selector
 }
 
 // NewPrinter creates a new [Printer].
 func NewPrinter(vw ValueWriter) *Printer {
 	return &Printer{
-		ValueWriter: vw,
-		Indent:      "\t",
+		ValueWriter:     vw,
+		Indent:          "\t",
+		Compact:         false,
+		PathAnnotation:  PathAnnotationNone,
+		PathGutterWidth: 32,
+		SymbolicAddr:    false,
+		Selector:        nil,
 	}
 }
 
@@ -82,7 +106,7 @@ func (p *Printer) WriteErr(w io.Writer, vi any) (err error) {
 }
 
 func (p *Printer) writeTo(w io.Writer, vi any) error {
-	st := newState(p.Indent)
+	st := newState(p)
 	defer st.release()
 	p.write(st, vi)
 	n, err := w.Write(st.Writer)
@@ -97,14 +121,19 @@ func (p *Printer) writeTo(w io.Writer, vi any) error {
 
 // String returns the value as a string.
 func (p *Printer) String(vi any) string {
-	st := newState(p.Indent)
+	st := newState(p)
 	defer st.release()
 	p.write(st, vi)
 	return st.Writer.String()
 }
 
 func (p *Printer) write(st *State, vi any) {
-	v := reflect.ValueOf(vi)
+	st.WriteIndent() // Starts the first line.
+	p.writeValue(st, reflect.ValueOf(vi))
+	st.trimEmptyGutterLine()
+}
+
+func (p *Printer) writeValue(st *State, v reflect.Value) {
 	if checkInvalidNil(st, v) {
 		return
 	}
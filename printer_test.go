@@ -20,6 +20,51 @@ func init() {
 			Value:        DefaultPrinter.Load(),
 			IgnoreResult: true,
 		},
+		{
+			Name: "Compact",
+			Value: map[string]any{
+				"struct": testStruct{
+					Foo: 123,
+					Bar: 123.456,
+				},
+				"slice": []string{"a", "b"},
+				"empty": []int{},
+			},
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+		},
+		{
+			Name:  "CompactTruncated",
+			Value: []int{1, 2, 3},
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Slice.MaxLen = 2
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "SymbolicAddr",
+			Value: func() any {
+				i := 123
+				s := []int{1, 2}
+				m := map[string]int{"a": 1}
+				c := make(chan int)
+				f := testSymbolicAddrFunc
+				r := &testSymbolicAddrRecursive{}
+				r.Self = r
+				return []any{&i, &i, s, s[1:], m, m, "test", c, f, f, r}
+			}(),
+			ConfigurePrinter: func(p *Printer) {
+				p.SymbolicAddr = true
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.SetShowAddr(true)
+			},
+			IgnoreBenchmark: true,
+		},
 	})
 }
 
@@ -131,3 +176,9 @@ func TestFormatter(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func testSymbolicAddrFunc() {}
+
+type testSymbolicAddrRecursive struct {
+	Self *testSymbolicAddrRecursive
+}
@@ -45,29 +45,25 @@ func (vw *RangeWriter) WriteValue(st *State, v reflect.Value) bool {
 	st.IndentLevel++
 	i := 0
 	m.Func.Call([]reflect.Value{v, reflect.MakeFunc(m.Type.In(1), func(args []reflect.Value) []reflect.Value {
-		if i == 0 {
-			st.Writer.AppendByte('\n')
-		}
-		st.WriteIndent()
 		if vw.MaxLen > 0 && i >= vw.MaxLen {
-			writeTruncated(st)
-			st.Writer.AppendByte('\n')
+			writeBlockTruncated(st, i == 0)
 			return rangeReturnFalse
 		}
+		st.PushPath(PathElement{Kind: PathElementKey, Key: args[0]})
+		st.WriteBlockItemStart(i == 0)
 		showInfos := st.ShowInfos
 		st.ShowInfos = vw.ShowKeysInfos
 		vw.ValueWriter.WriteValue(st, args[0])
 		st.ShowInfos = showInfos
 		st.Writer.AppendString(": ")
 		vw.ValueWriter.WriteValue(st, args[1])
-		st.Writer.AppendString(",\n")
+		st.WriteBlockItemEnd()
+		st.PopPath()
 		i++
 		return rangeReturnTrue
 	})})
 	st.IndentLevel--
-	if i != 0 {
-		st.WriteIndent()
-	}
+	st.WriteBlockEnd(i != 0)
 	st.Writer.AppendByte('}')
 	return true
 }
@@ -82,6 +78,11 @@ func (vw *RangeWriter) Supports(typ reflect.Type) ValueWriter {
 }
 
 func (vw *RangeWriter) getMethod(typ reflect.Type) (reflect.Method, bool) {
+	return getRangeMethod(typ)
+}
+
+// getRangeMethod returns the "Range" [iter.Seq2] method of the type.
+func getRangeMethod(typ reflect.Type) (reflect.Method, bool) {
 	m, ok := reflectutil.GetMethods(typ).GetByName("Range")
 	if !ok {
 		return m, false
@@ -2,25 +2,49 @@ package pretty
 
 import (
 	"reflect"
+	"slices"
+	"strconv"
 
 	"github.com/pierrre/go-libs/reflectutil"
 )
 
 // RecursionWriter is a [ValueWriter] that prevents recursion.
 //
+// With ShowReferences, it also identifies shared references.
+//
 // It should be created with [NewRecursionWriter].
 type RecursionWriter struct {
 	ValueWriter
 	// ShowAddr shows the address (and type).
 	// Default: true.
 	ShowAddr bool
+	// ShowPath shows the path of the visited ancestor, e.g. "<recursion: $.Nodes[3]>".
+	// "$" is the root value.
+	// Default: false.
+	ShowPath bool
+	// RelativePath shows the path of the visited ancestor relative to the value containing the recursive value (with ShowPath).
+	// Each "^" is a level up from the containing value to the ancestor, and it is followed by the field, index or key that refers to the ancestor.
+	// E.g. "<recursion: ^^.Parent>" for the field Parent of a value in the slice Children of the ancestor.
+	// "." is written if the recursive value is the ancestor itself.
+	// Default: false.
+	RelativePath bool
+	// ShowReferences labels pointer, map and slice targets with "&1", "&2", etc. the first time they are written.
+	// The next occurrences are written as a reference "*1", "*2", etc.
+	// The labels are assigned in the writing order, so they are deterministic.
+	// The values whose content is not written (e.g. summarized by [MaxDepthWriter]) are not labeled.
+	// It replaces the recursion detection, because a recursion is also a reference.
+	// Default: false.
+	ShowReferences bool
 }
 
 // NewRecursionWriter creates a new [RecursionWriter].
 func NewRecursionWriter(vw ValueWriter) *RecursionWriter {
 	return &RecursionWriter{
-		ValueWriter: vw,
-		ShowAddr:    true,
+		ValueWriter:    vw,
+		ShowAddr:       true,
+		ShowPath:       false,
+		RelativePath:   false,
+		ShowReferences: false,
 	}
 }
 
@@ -33,6 +57,11 @@ func (vw *RecursionWriter) WriteValue(st *State, v reflect.Value) bool {
 	if visitedAdded {
 		defer vw.postRecursion(st, e)
 	}
+	if vw.ShowReferences {
+		defer func() {
+			st.reference = referenceLabel{} // The label can only be dropped while the value is written.
+		}()
+	}
 	return vw.ValueWriter.WriteValue(st, v)
 }
 
@@ -42,27 +71,140 @@ func (vw *RecursionWriter) checkRecursion(st *State, v reflect.Value) (e Visited
 	default:
 		return VisitedEntry{}, false, false
 	}
+	if vw.ShowReferences {
+		return VisitedEntry{}, false, checkReference(st, v)
+	}
 	e = VisitedEntry{
 		Type: v.Type(),
 		Addr: uintptr(v.UnsafePointer()),
 	}
-	if _, ok := st.Visited[e]; !ok {
+	_, ok := st.Visited[e]
+	if !ok {
 		if st.Visited == nil {
 			st.Visited = make(map[VisitedEntry]struct{})
 		}
 		st.Visited[e] = struct{}{}
+		if vw.ShowPath {
+			if st.visitedPathLens == nil {
+				st.visitedPathLens = make(map[VisitedEntry]int)
+			}
+			st.visitedPathLens[e] = len(st.Path)
+		}
 		return e, true, false
 	}
-	st.Writer.AppendString("<recursion>")
+	st.Writer.AppendString("<recursion")
+	if vw.ShowPath {
+		st.Writer.AppendString(": ")
+		vw.writePath(st, st.visitedPathLens[e])
+	}
+	st.Writer.AppendByte('>')
 	if vw.ShowAddr {
 		st.Writer.AppendByte(' ')
 		st.Writer.AppendString(reflectutil.TypeFullName(e.Type))
 		st.Writer.AppendByte(' ')
-		writeUintptr(st, e.Addr)
+		writeAddr(st, e.Addr)
 	}
 	return VisitedEntry{}, false, true
 }
 
+func (vw *RecursionWriter) writePath(st *State, pathLen int) {
+	if !vw.RelativePath {
+		st.Writer.AppendByte('$')
+		st.Writer = st.Path[:pathLen].Append(st.Writer)
+		return
+	}
+	up := len(st.Path) - pathLen
+	if up == 0 {
+		st.Writer.AppendByte('.')
+		return
+	}
+	for range up - 1 { // The first level up is the containing value.
+		st.Writer.AppendByte('^')
+	}
+	st.Writer = st.Path[len(st.Path)-1:].Append(st.Writer)
+}
+
 func (vw *RecursionWriter) postRecursion(st *State, e VisitedEntry) {
 	delete(st.Visited, e)
+	delete(st.visitedPathLens, e)
+}
+
+type referenceEntry struct {
+	typ  reflect.Type
+	addr uintptr
+	len  int
+}
+
+func checkReference(st *State, v reflect.Value) bool {
+	if v.IsNil() {
+		return false
+	}
+	typ := v.Type()
+	e := referenceEntry{
+		typ:  typ,
+		addr: uintptr(v.UnsafePointer()),
+	}
+	switch typ.Kind() { //nolint:exhaustive // Only handles pointer kinds.
+	case reflect.Pointer:
+		if typ.Elem().Size() == 0 {
+			return false // Pointers to zero-sized values can share the same address.
+		}
+	case reflect.Slice:
+		e.len = v.Len()
+		if e.len == 0 || typ.Elem().Size() == 0 {
+			return false // Empty slices can share the same address.
+		}
+	}
+	if id, ok := st.references[e]; ok {
+		st.Writer.AppendByte('*')
+		st.Writer = strconv.AppendInt(st.Writer, int64(id), 10)
+		return true
+	}
+	if st.references == nil {
+		st.references = make(map[referenceEntry]int)
+	}
+	id := len(st.references) + 1
+	st.references[e] = id
+	start := len(st.Writer)
+	st.Writer.AppendByte('&')
+	st.Writer = strconv.AppendInt(st.Writer, int64(id), 10)
+	st.Writer.AppendByte(' ')
+	st.reference = referenceLabel{
+		entry: e,
+		start: start,
+		end:   len(st.Writer),
+		depth: st.Depth,
+	}
+	return false
+}
+
+// referenceLabel is the label of the value being written by [RecursionWriter].
+type referenceLabel struct {
+	entry referenceEntry
+	start int
+	end   int
+	// depth is the [State.Depth] of the value.
+	depth int
+}
+
+// dropReferenceLabel removes the label of the value being written, because its content is not written (e.g. [MaxDepthWriter] writes a summary).
+//
+// The depth is the [State.Depth] of the value that is not written.
+// It is the labeled value, or the target of the labeled pointer.
+// The label is assigned again to the next occurrence of the value, so the references always point to a written value.
+func dropReferenceLabel(st *State, depth int) {
+	l := st.reference
+	if l.end == 0 {
+		return
+	}
+	if depth != l.depth && (depth != l.depth+1 || l.entry.typ.Kind() != reflect.Pointer) {
+		return
+	}
+	st.Writer = slices.Delete(st.Writer, l.start, l.end)
+	if st.size.pending && st.size.start >= l.end {
+		st.size.start -= l.end - l.start
+		st.size.end -= l.end - l.start
+	}
+	delete(st.references, l.entry)
+	st.reference = referenceLabel{}
 }
@@ -2,6 +2,7 @@ package pretty_test
 
 import (
 	"reflect"
+	"weak"
 
 	. "github.com/pierrre/pretty"
 	"github.com/pierrre/pretty/internal/prettytest"
@@ -45,6 +46,139 @@ func init() {
 			},
 			IgnoreResult: true,
 		},
+		{
+			Name:  "Path",
+			Value: newTestRecursionGraph(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Recursion.ShowPath = true
+			},
+		},
+		{
+			Name:  "RelativePath",
+			Value: newTestRecursionGraph(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Recursion.ShowPath = true
+				vw.Recursion.RelativePath = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "RelativePathSlice",
+			Value: func() []any {
+				v := make([]any, 1)
+				v[0] = v
+				return v
+			}(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Recursion.ShowPath = true
+				vw.Recursion.RelativePath = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "HidePath",
+			Value:           newTestRecursionGraph(),
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "References",
+			Value: func() any {
+				type node struct {
+					Name  string
+					Next  *node
+					Items []int
+				}
+				shared := &node{Name: "shared", Items: []int{1, 2}}
+				cycle := &node{Name: "cycle"}
+				cycle.Next = cycle
+				items := []int{3, 4}
+				return []any{
+					shared,
+					&node{Name: "other", Next: shared, Items: shared.Items},
+					cycle,
+					items,
+					items[:1],
+					map[string]*node{"a": shared},
+					weak.Make(shared),
+					&struct{}{},
+					&struct{}{},
+				}
+			}(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Recursion.ShowReferences = true
+			},
+		},
+		{
+			Name:  "ReferencesCycle",
+			Value: newTestRecursionGraph(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Recursion.ShowReferences = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "ReferencesCycleMap",
+			Value: func() any {
+				m := map[string]any{}
+				m["self"] = m
+				m["slice"] = []any{m}
+				return m
+			}(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Recursion.ShowReferences = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "ReferencesMaxDepth",
+			Value: func() any {
+				shared := &testRecursionNode{Name: "shared"}
+				return []any{
+					[]*testRecursionNode{shared},
+					shared,
+				}
+			}(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Recursion.ShowReferences = true
+				vw.MaxDepth.MaxByPath = map[string]int{
+					"[0]": 1,
+				}
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "ReferencesMaxDepthPointer",
+			Value: func() any {
+				shared := &testRecursionNode{Name: "shared"}
+				return []any{
+					[]*testRecursionNode{shared},
+					shared,
+				}
+			}(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Recursion.ShowReferences = true
+				vw.MaxDepth.MaxByPath = map[string]int{
+					"[0]": 2,
+				}
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "ReferencesCollapseRepeated",
+			Value: func() any {
+				shared := &testRecursionNode{Name: "shared"}
+				equal := &testRecursionNode{Name: "shared"}
+				return []any{
+					[]*testRecursionNode{shared, shared, equal},
+					equal,
+				}
+			}(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Recursion.ShowReferences = true
+				vw.Kind.Slice.CollapseRepeated = true
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:  "Disabled",
 			Value: "test",
@@ -88,3 +222,19 @@ func init() {
 		},
 	})
 }
+
+type testRecursionNode struct {
+	Name     string
+	Parent   *testRecursionNode
+	Children []*testRecursionNode
+}
+
+func newTestRecursionGraph() *testRecursionNode {
+	root := &testRecursionNode{Name: "root"}
+	for _, name := range []string{"a", "b"} {
+		root.Children = append(root.Children, &testRecursionNode{Name: name, Parent: root})
+	}
+	b := root.Children[1]
+	b.Children = []*testRecursionNode{{Name: "c", Parent: b}}
+	return root
+}
@@ -95,12 +95,22 @@ var reflectTypeImplementsCache = reflectutil.NewImplementsCacheFor[reflect.Type]
 // ReflectTypeWriter is a [ValueWriter] that handles [reflect.Type].
 type ReflectTypeWriter struct {
 	ValueWriter
+	// Declaration writes the type as a Go declaration, e.g. "type T struct { A int `json:"a"`; B *U }", instead of its properties.
+	// The anonymous types are expanded, and the method set is written in a "methods" block.
+	// Default: false.
+	Declaration bool
+	// DeclarationDepth is the depth of the declarations of the named types referenced by the declaration (e.g. field types).
+	// 0 means that only the type itself is declared.
+	// Default: 0.
+	DeclarationDepth int
 }
 
 // NewReflectTypeWriter returns a new [ReflectTypeWriter].
 func NewReflectTypeWriter(vw ValueWriter) *ReflectTypeWriter {
 	return &ReflectTypeWriter{
-		ValueWriter: vw,
+		ValueWriter:      vw,
+		Declaration:      false,
+		DeclarationDepth: 0,
 	}
 }
 
@@ -113,13 +123,17 @@ func (vw *ReflectTypeWriter) WriteValue(st *State, v reflect.Value) bool {
 	if !ok {
 		return false
 	}
+	if vw.Declaration {
+		vw.writeDeclarations(st, typ)
+		return true
+	}
 	st.Writer.AppendString("reflect.Type ")
 	vw.writeType(st, typ)
 	return true
 }
 
 func (vw *ReflectTypeWriter) writeType(st *State, typ reflect.Type) {
-	st.Writer.AppendString("{\n")
+	st.Writer.AppendByte('{')
 	st.IndentLevel++
 	vw.writeTypeFullName(st, typ)
 	vw.writeTypePkgPath(st, typ)
@@ -133,18 +147,17 @@ func (vw *ReflectTypeWriter) writeType(st *State, typ reflect.Type) {
 	vw.writeTypeElem(st, typ)
 	vw.writeTypeChan(st, typ)
 	vw.writeTypeStruct(st, typ)
-	vw.writeTypeFunc(st, typ, false)
+	vw.writeTypeFunc(st, typ, false, false)
 	vw.writeTypeMethods(st, typ)
 	st.IndentLevel--
-	st.WriteIndent()
+	st.WriteBlockEnd(true)
 	st.Writer.AppendByte('}')
 }
 
 func (vw *ReflectTypeWriter) writeTypeFullName(st *State, typ reflect.Type) {
-	st.WriteIndent()
-	st.Writer.AppendString("FullName: ")
+	vw.writePropertyStart(st, "FullName", true)
 	st.Writer.AppendString(reflectutil.TypeFullName(typ))
-	st.Writer.AppendString(",\n")
+	vw.writePropertyEnd(st)
 }
 
 func (vw *ReflectTypeWriter) writeTypePkgPath(st *State, typ reflect.Type) {
@@ -152,10 +165,9 @@ func (vw *ReflectTypeWriter) writeTypePkgPath(st *State, typ reflect.Type) {
 	if pkgPath == "" {
 		return
 	}
-	st.WriteIndent()
-	st.Writer.AppendString("PkgPath: ")
+	vw.writePropertyStart(st, "PkgPath", false)
 	st.Writer.AppendString(pkgPath)
-	st.Writer.AppendString(",\n")
+	vw.writePropertyEnd(st)
 }
 
 func (vw *ReflectTypeWriter) writeTypeName(st *State, typ reflect.Type) {
@@ -163,31 +175,27 @@ func (vw *ReflectTypeWriter) writeTypeName(st *State, typ reflect.Type) {
 	if name == "" {
 		return
 	}
-	st.WriteIndent()
-	st.Writer.AppendString("Name: ")
+	vw.writePropertyStart(st, "Name", false)
 	st.Writer.AppendString(name)
-	st.Writer.AppendString(",\n")
+	vw.writePropertyEnd(st)
 }
 
 func (vw *ReflectTypeWriter) writeTypeString(st *State, typ reflect.Type) {
-	st.WriteIndent()
-	st.Writer.AppendString("String: ")
+	vw.writePropertyStart(st, "String", false)
 	st.Writer.AppendString(typ.String())
-	st.Writer.AppendString(",\n")
+	vw.writePropertyEnd(st)
 }
 
 func (vw *ReflectTypeWriter) writeTypeKind(st *State, typ reflect.Type) {
-	st.WriteIndent()
-	st.Writer.AppendString("Kind: ")
+	vw.writePropertyStart(st, "Kind", false)
 	st.Writer.AppendString(typ.Kind().String())
-	st.Writer.AppendString(",\n")
+	vw.writePropertyEnd(st)
 }
 
 func (vw *ReflectTypeWriter) writeTypeSize(st *State, typ reflect.Type) {
-	st.WriteIndent()
-	st.Writer.AppendString("Size: ")
+	vw.writePropertyStart(st, "Size", false)
 	st.Writer = strconv.AppendUint(st.Writer, uint64(typ.Size()), 10)
-	st.Writer.AppendString(",\n")
+	vw.writePropertyEnd(st)
 }
 
 func (vw *ReflectTypeWriter) writeTypeUnderlying(st *State, typ reflect.Type) {
@@ -195,30 +203,27 @@ func (vw *ReflectTypeWriter) writeTypeUnderlying(st *State, typ reflect.Type) {
 	if uTyp == typ {
 		return
 	}
-	st.WriteIndent()
-	st.Writer.AppendString("Underlying: ")
+	vw.writePropertyStart(st, "Underlying", false)
 	vw.ValueWriter.WriteValue(st, reflect.ValueOf(uTyp))
-	st.Writer.AppendString(",\n")
+	vw.writePropertyEnd(st)
 }
 
 func (vw *ReflectTypeWriter) writeTypeLen(st *State, typ reflect.Type) {
 	if typ.Kind() != reflect.Array {
 		return
 	}
-	st.WriteIndent()
-	st.Writer.AppendString("Len: ")
+	vw.writePropertyStart(st, "Len", false)
 	st.Writer = strconv.AppendInt(st.Writer, int64(typ.Len()), 10)
-	st.Writer.AppendString(",\n")
+	vw.writePropertyEnd(st)
 }
 
 func (vw *ReflectTypeWriter) writeTypeKey(st *State, typ reflect.Type) {
 	if typ.Kind() != reflect.Map {
 		return
 	}
-	st.WriteIndent()
-	st.Writer.AppendString("Key: ")
+	vw.writePropertyStart(st, "Key", false)
 	vw.ValueWriter.WriteValue(st, reflect.ValueOf(typ.Key()))
-	st.Writer.AppendString(",\n")
+	vw.writePropertyEnd(st)
 }
 
 func (vw *ReflectTypeWriter) writeTypeElem(st *State, typ reflect.Type) {
@@ -227,20 +232,18 @@ func (vw *ReflectTypeWriter) writeTypeElem(st *State, typ reflect.Type) {
 	default:
 		return
 	}
-	st.WriteIndent()
-	st.Writer.AppendString("Elem: ")
+	vw.writePropertyStart(st, "Elem", false)
 	vw.ValueWriter.WriteValue(st, reflect.ValueOf(typ.Elem()))
-	st.Writer.AppendString(",\n")
+	vw.writePropertyEnd(st)
 }
 
 func (vw *ReflectTypeWriter) writeTypeChan(st *State, typ reflect.Type) {
 	if typ.Kind() != reflect.Chan {
 		return
 	}
-	st.WriteIndent()
-	st.Writer.AppendString("ChanDir: ")
+	vw.writePropertyStart(st, "ChanDir", false)
 	st.Writer.AppendString(typ.ChanDir().String())
-	st.Writer.AppendString(",\n")
+	vw.writePropertyEnd(st)
 }
 
 func (vw *ReflectTypeWriter) writeTypeStruct(st *State, typ reflect.Type) {
@@ -251,11 +254,12 @@ func (vw *ReflectTypeWriter) writeTypeStruct(st *State, typ reflect.Type) {
 	if fields.Len() == 0 {
 		return
 	}
-	st.WriteIndent()
-	st.Writer.AppendString("Fields: {\n")
+	vw.writePropertyStart(st, "Fields", false)
+	st.Writer.AppendByte('{')
 	st.IndentLevel++
-	fields.Range(func(_ int, f reflect.StructField) bool {
-		st.WriteIndent()
+	fields.Range(func(i int, f reflect.StructField) bool {
+		st.PushPath(PathElement{Kind: PathElementField, Name: f.Name})
+		st.WriteBlockItemStart(i == 0)
 		st.Writer.AppendString(f.Name)
 		st.Writer.AppendString(" ")
 		st.Writer.AppendString(reflectutil.TypeFullName(f.Type))
@@ -264,42 +268,51 @@ func (vw *ReflectTypeWriter) writeTypeStruct(st *State, typ reflect.Type) {
 			st.Writer.AppendString(string(f.Tag))
 			st.Writer.AppendString("`")
 		}
-		st.Writer.AppendString(",\n")
+		st.WriteBlockItemEnd()
+		st.PopPath()
 		return true
 	})
 	st.IndentLevel--
-	st.WriteIndent()
-	st.Writer.AppendString("},\n")
+	st.WriteBlockEnd(true)
+	st.Writer.AppendByte('}')
+	vw.writePropertyEnd(st)
 }
 
-func (vw *ReflectTypeWriter) writeTypeFunc(st *State, typ reflect.Type, ignoreReceiver bool) {
+func (vw *ReflectTypeWriter) writeTypeFunc(st *State, typ reflect.Type, ignoreReceiver bool, first bool) (wrote bool) {
 	if typ.Kind() != reflect.Func {
-		return
+		return false
+	}
+	wrote = vw.writeTypeFuncParameters(st, "In", typ.NumIn(), typ.In, ignoreReceiver, first)
+	if vw.writeTypeFuncParameters(st, "Out", typ.NumOut(), typ.Out, false, first && !wrote) {
+		wrote = true
 	}
-	vw.writeTypeFuncParameters(st, "In", typ.NumIn(), typ.In, ignoreReceiver)
-	vw.writeTypeFuncParameters(st, "Out", typ.NumOut(), typ.Out, false)
+	return wrote
 }
 
-func (vw *ReflectTypeWriter) writeTypeFuncParameters(st *State, name string, count int, get func(int) reflect.Type, ignoreFirst bool) {
+func (vw *ReflectTypeWriter) writeTypeFuncParameters(st *State, name string, count int, get func(int) reflect.Type, ignoreFirst bool, first bool) bool {
 	if (ignoreFirst && count == 1) || (!ignoreFirst && count == 0) {
-		return
+		return false
 	}
-	st.WriteIndent()
-	st.Writer.AppendString(name)
-	st.Writer.AppendString(": (\n")
+	vw.writePropertyStart(st, name, first)
+	st.Writer.AppendByte('(')
 	st.IndentLevel++
-	for i := range count {
-		if ignoreFirst && i == 0 {
-			continue
-		}
+	start := 0
+	if ignoreFirst {
+		start = 1
+	}
+	for i := start; i < count; i++ {
 		typ := get(i)
-		st.WriteIndent()
+		st.PushPath(PathElement{Kind: PathElementIndex, Index: i - start})
+		st.WriteBlockItemStart(i == start)
 		st.Writer.AppendString(reflectutil.TypeFullName(typ))
-		st.Writer.AppendString(",\n")
+		st.WriteBlockItemEnd()
+		st.PopPath()
 	}
 	st.IndentLevel--
-	st.WriteIndent()
-	st.Writer.AppendString("),\n")
+	st.WriteBlockEnd(true)
+	st.Writer.AppendByte(')')
+	vw.writePropertyEnd(st)
+	return true
 }
 
 func (vw *ReflectTypeWriter) writeTypeMethods(st *State, typ reflect.Type) {
@@ -308,23 +321,282 @@ func (vw *ReflectTypeWriter) writeTypeMethods(st *State, typ reflect.Type) {
 		return
 	}
 	ignoreReceiver := typ.Kind() != reflect.Interface
-	st.WriteIndent()
-	st.Writer.AppendString("Methods: {\n")
+	vw.writePropertyStart(st, "Methods", false)
+	st.Writer.AppendByte('{')
 	st.IndentLevel++
-	methods.Range(func(_ int, m reflect.Method) bool {
-		st.WriteIndent()
-		st.Writer.AppendString(m.Name)
-		st.Writer.AppendString(": {\n")
+	methods.Range(func(i int, m reflect.Method) bool {
+		vw.writePropertyStart(st, m.Name, i == 0)
+		st.Writer.AppendByte('{')
 		st.IndentLevel++
-		vw.writeTypeFunc(st, m.Type, ignoreReceiver)
+		wrote := vw.writeTypeFunc(st, m.Type, ignoreReceiver, true)
 		st.IndentLevel--
+		st.WriteBlockEnd(wrote)
+		st.Writer.AppendByte('}')
+		vw.writePropertyEnd(st)
+		return true
+	})
+	st.IndentLevel--
+	st.WriteBlockEnd(true)
+	st.Writer.AppendByte('}')
+	vw.writePropertyEnd(st)
+}
+
+func (vw *ReflectTypeWriter) writePropertyStart(st *State, name string, first bool) {
+	st.PushPath(PathElement{Kind: PathElementField, Name: name})
+	st.WriteBlockItemStart(first)
+	st.Writer.AppendString(name)
+	st.Writer.AppendString(": ")
+}
+
+func (vw *ReflectTypeWriter) writePropertyEnd(st *State) {
+	st.WriteBlockItemEnd()
+	st.PopPath()
+}
+
+type typeDeclaration struct {
+	typ   reflect.Type
+	depth int
+}
+
+// writeDeclarations writes the declaration of the type, followed by the declarations of the referenced named types, up to [ReflectTypeWriter.DeclarationDepth].
+func (vw *ReflectTypeWriter) writeDeclarations(st *State, typ reflect.Type) {
+	methodsTyp := typ
+	if typ.Kind() == reflect.Pointer && isDeclaredType(typ.Elem()) {
+		typ = typ.Elem() // Declares the named type, with the method set of the pointer.
+	}
+	declared := map[reflect.Type]bool{typ: true}
+	queue := []typeDeclaration{{typ: typ}}
+	for i := 0; i < len(queue); i++ {
+		decl := queue[i]
+		if i > 0 {
+			vw.writeDeclarationSeparator(st)
+		}
+		if decl.depth < vw.DeclarationDepth {
+			decl.depth++
+		} else {
+			decl.depth = -1 // Doesn't collect the referenced types.
+		}
+		enqueue := func(typ reflect.Type) {
+			if decl.depth >= 0 && !declared[typ] {
+				declared[typ] = true
+				queue = append(queue, typeDeclaration{typ: typ, depth: decl.depth})
+			}
+		}
+		if isDeclaredType(decl.typ) {
+			st.Writer.AppendString("type ")
+			st.Writer.AppendString(reflectutil.TypeFullName(decl.typ))
+			st.Writer.AppendByte(' ')
+		}
+		writeTypeExpression(st, decl.typ, true, enqueue)
+		if i == 0 {
+			writeTypeMethodSet(st, methodsTyp, enqueue)
+		} else {
+			writeTypeMethodSet(st, decl.typ, enqueue)
+		}
+	}
+}
+
+func (vw *ReflectTypeWriter) writeDeclarationSeparator(st *State) {
+	if st.Compact {
+		st.Writer.AppendString("; ")
+		return
+	}
+	st.writeNewLine()
+	st.writeNewLine()
+	st.WriteIndent()
+}
+
+// isDeclaredType returns true if the type is named and not predeclared.
+func isDeclaredType(typ reflect.Type) bool {
+	return typ.Name() != "" && typ.PkgPath() != ""
+}
+
+// writeTypeExpression writes the Go expression of a type.
+//
+// The named types are not expanded (except the top level type), and they are passed to the enqueue function.
+//
+//nolint:gocyclo // We need to handle all kinds.
+func writeTypeExpression(st *State, typ reflect.Type, top bool, enqueue func(reflect.Type)) {
+	if !top && typ.Name() != "" {
+		st.Writer.AppendString(reflectutil.TypeFullName(typ))
+		if isDeclaredType(typ) {
+			enqueue(typ)
+		}
+		return
+	}
+	switch typ.Kind() { //nolint:exhaustive // Other kinds are predeclared types.
+	case reflect.Pointer:
+		st.Writer.AppendByte('*')
+		writeTypeExpression(st, typ.Elem(), false, enqueue)
+	case reflect.Slice:
+		st.Writer.AppendString("[]")
+		writeTypeExpression(st, typ.Elem(), false, enqueue)
+	case reflect.Array:
+		st.Writer.AppendByte('[')
+		st.Writer = strconv.AppendInt(st.Writer, int64(typ.Len()), 10)
+		st.Writer.AppendByte(']')
+		writeTypeExpression(st, typ.Elem(), false, enqueue)
+	case reflect.Map:
+		st.Writer.AppendString("map[")
+		writeTypeExpression(st, typ.Key(), false, enqueue)
+		st.Writer.AppendByte(']')
+		writeTypeExpression(st, typ.Elem(), false, enqueue)
+	case reflect.Chan:
+		writeTypeChanExpression(st, typ, enqueue)
+	case reflect.Func:
+		st.Writer.AppendString("func")
+		writeTypeFuncSignature(st, typ, false, enqueue)
+	case reflect.Struct:
+		writeTypeStructExpression(st, typ, enqueue)
+	case reflect.Interface:
+		st.Writer.AppendString("interface")
+		writeTypeMethods(st, typ, false, enqueue)
+	default:
+		st.Writer.AppendString(typ.Kind().String())
+	}
+}
+
+func writeTypeChanExpression(st *State, typ reflect.Type, enqueue func(reflect.Type)) {
+	elem := typ.Elem()
+	switch typ.ChanDir() {
+	case reflect.RecvDir:
+		st.Writer.AppendString("<-chan ")
+	case reflect.SendDir:
+		st.Writer.AppendString("chan<- ")
+	case reflect.BothDir:
+		st.Writer.AppendString("chan ")
+		if elem.Name() == "" && elem.Kind() == reflect.Chan && elem.ChanDir() == reflect.RecvDir {
+			st.Writer.AppendByte('(')
+			writeTypeExpression(st, elem, false, enqueue)
+			st.Writer.AppendByte(')')
+			return
+		}
+	}
+	writeTypeExpression(st, elem, false, enqueue)
+}
+
+func writeTypeStructExpression(st *State, typ reflect.Type, enqueue func(reflect.Type)) {
+	st.Writer.AppendString("struct")
+	fields := reflectutil.GetStructFields(typ)
+	if fields.Len() == 0 {
+		st.Writer.AppendString("{}")
+		return
+	}
+	writeTypeBlockStart(st)
+	fields.Range(func(i int, f reflect.StructField) bool {
+		st.PushPath(PathElement{Kind: PathElementField, Name: f.Name})
+		writeTypeBlockItemStart(st, i == 0)
+		if !f.Anonymous {
+			st.Writer.AppendString(f.Name)
+			st.Writer.AppendByte(' ')
+		}
+		writeTypeExpression(st, f.Type, false, enqueue)
+		if f.Tag != "" {
+			st.Writer.AppendString(" `")
+			st.Writer.AppendString(string(f.Tag))
+			st.Writer.AppendByte('`')
+		}
+		st.PopPath()
+		return true
+	})
+	writeTypeBlockEnd(st)
+}
+
+// writeTypeMethodSet writes the method set of a non-interface type in a "methods" block.
+func writeTypeMethodSet(st *State, typ reflect.Type, enqueue func(reflect.Type)) {
+	if typ.Kind() == reflect.Interface || typ.NumMethod() == 0 {
+		return
+	}
+	if st.Compact {
+		st.Writer.AppendByte(' ')
+	} else {
+		st.writeNewLine()
 		st.WriteIndent()
-		st.Writer.AppendString("},\n")
+	}
+	st.Writer.AppendString("methods")
+	writeTypeMethods(st, typ, true, enqueue)
+}
+
+func writeTypeMethods(st *State, typ reflect.Type, ignoreReceiver bool, enqueue func(reflect.Type)) {
+	methods := reflectutil.GetMethods(typ)
+	if methods.Len() == 0 {
+		st.Writer.AppendString("{}")
+		return
+	}
+	writeTypeBlockStart(st)
+	methods.Range(func(i int, m reflect.Method) bool {
+		writeTypeBlockItemStart(st, i == 0)
+		st.Writer.AppendString(m.Name)
+		writeTypeFuncSignature(st, m.Type, ignoreReceiver, enqueue)
 		return true
 	})
+	writeTypeBlockEnd(st)
+}
+
+func writeTypeFuncSignature(st *State, typ reflect.Type, ignoreReceiver bool, enqueue func(reflect.Type)) {
+	st.Writer.AppendByte('(')
+	start := 0
+	if ignoreReceiver {
+		start = 1
+	}
+	for i := start; i < typ.NumIn(); i++ {
+		if i > start {
+			st.Writer.AppendString(", ")
+		}
+		in := typ.In(i)
+		if typ.IsVariadic() && i == typ.NumIn()-1 {
+			st.Writer.AppendString("...")
+			in = in.Elem()
+		}
+		writeTypeExpression(st, in, false, enqueue)
+	}
+	st.Writer.AppendByte(')')
+	if typ.NumOut() == 0 {
+		return
+	}
+	st.Writer.AppendByte(' ')
+	parens := typ.NumOut() > 1
+	if parens {
+		st.Writer.AppendByte('(')
+	}
+	for i := range typ.NumOut() {
+		if i > 0 {
+			st.Writer.AppendString(", ")
+		}
+		writeTypeExpression(st, typ.Out(i), false, enqueue)
+	}
+	if parens {
+		st.Writer.AppendByte(')')
+	}
+}
+
+func writeTypeBlockStart(st *State) {
+	st.Writer.AppendString(" {")
+	st.IndentLevel++
+}
+
+func writeTypeBlockItemStart(st *State, first bool) {
+	if st.Compact {
+		if first {
+			st.Writer.AppendByte(' ')
+		} else {
+			st.Writer.AppendString("; ")
+		}
+		return
+	}
+	st.writeNewLine()
+	st.WriteIndent()
+}
+
+func writeTypeBlockEnd(st *State) {
 	st.IndentLevel--
+	if st.Compact {
+		st.Writer.AppendString(" }")
+		return
+	}
+	st.writeNewLine()
 	st.WriteIndent()
-	st.Writer.AppendString("},\n")
+	st.Writer.AppendByte('}')
 }
 
 // Supports implements [SupportChecker].
@@ -133,6 +133,14 @@ func init() {
 				return reflect.TypeFor[CustomStruct]()
 			}(),
 		},
+		{
+			Name:  "Compact",
+			Value: reflect.TypeFor[*bytes.Buffer](),
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name: "RecursivePointer",
 			Value: func() reflect.Type {
@@ -188,5 +196,87 @@ func init() {
 			},
 			IgnoreBenchmark: true,
 		},
+		{
+			Name:            "Declaration",
+			Value:           reflect.TypeFor[testDeclStruct](),
+			ConfigureWriter: configureTestDeclaration,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "DeclarationPointer",
+			Value:           reflect.TypeFor[*testDeclStruct](),
+			ConfigureWriter: configureTestDeclaration,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "DeclarationDepth",
+			Value: reflect.TypeFor[*testDeclStruct](),
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestDeclaration(vw)
+				vw.Reflect.Type.DeclarationDepth = 2
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "DeclarationCompact",
+			Value: reflect.TypeFor[testDeclChild](),
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestDeclaration(vw)
+				vw.Reflect.Type.DeclarationDepth = 1
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "DeclarationInterface",
+			Value:           reflect.TypeFor[io.ReadCloser](),
+			ConfigureWriter: configureTestDeclaration,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "DeclarationUnnamed",
+			Value:           reflect.TypeFor[map[string][]chan (<-chan int)](),
+			ConfigureWriter: configureTestDeclaration,
+			IgnoreBenchmark: true,
+		},
 	})
 }
+
+func configureTestDeclaration(vw *CommonWriter) {
+	vw.Reflect.Type.Declaration = true
+}
+
+type testDeclStruct struct {
+	Name   string `json:"name"`
+	Child  *testDeclChild
+	Nested struct {
+		X int
+		Y []testDeclChild
+	}
+	testDeclEmbedded
+	Func  func(int, ...string) (bool, error)
+	Chan  chan<- struct{}
+	Map   map[string]any
+	Empty interface{}
+}
+
+func (*testDeclStruct) Set(int) {}
+
+func (testDeclStruct) Get() int {
+	return 0
+}
+
+type testDeclChild struct {
+	Value  testDeclEnum
+	Parent *testDeclStruct
+}
+
+type testDeclEnum int
+
+func (testDeclEnum) String() string {
+	return ""
+}
+
+type testDeclEmbedded struct{}
@@ -0,0 +1,114 @@
+package pretty
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Search returns the values matching the predicate with [DefaultPrinter].
+func Search(vi any, pred SearchPredicate) []Selection {
+	return DefaultPrinter.Load().Search(vi, pred)
+}
+
+// StringSearch returns the values matching the predicate as a string with [DefaultPrinter].
+func StringSearch(vi any, pred SearchPredicate) string {
+	return DefaultPrinter.Load().StringSearch(vi, pred)
+}
+
+// SearchPredicate is a function that returns true if a value matches.
+//
+// The value can be used with [reflect.Value.Interface] if possible, even if it was obtained from an unexported field (see [CanInterfaceWriter]).
+type SearchPredicate func(v reflect.Value) bool
+
+// Search returns the values matching the predicate, at any depth (including the root value).
+//
+// It uses the same traversal as [Selector.Select] with the path ".**".
+func (s *Selector) Search(vi any, pred SearchPredicate) []Selection {
+	return s.search(vi, pred, nil)
+}
+
+// Search returns the values matching the predicate (see [Selector.Search]).
+//
+// It uses the [Selector] of the [Printer].
+// If the [ValueWriter] is a [CommonWriter], the values are visited as when they are written:
+//   - the values are converted for [reflect.Value.Interface] only if [CommonWriter.CanInterface] is enabled
+//   - the children of the values handled by [CommonWriter.ByType] are not visited
+//   - the limits of [CommonWriter.MaxDepth] are applied (see [Printer.Stats])
+func (p *Printer) Search(vi any, pred SearchPredicate) []Selection {
+	vw, _ := p.ValueWriter.(*CommonWriter)
+	return p.getSelector().search(vi, pred, vw)
+}
+
+// StringSearch returns the values matching the predicate (see [Printer.Search]) as a string.
+//
+// Each value is written on a new line, prefixed by its path.
+func (p *Printer) StringSearch(vi any, pred SearchPredicate) string {
+	st := newState(p)
+	defer st.release()
+	p.writeSelections(st, p.Search(vi, pred))
+	return st.Writer.String()
+}
+
+func (s *Selector) search(vi any, pred SearchPredicate, vw *CommonWriter) []Selection {
+	ss := &selectState{
+		selector:  s,
+		predicate: pred,
+		writer:    vw,
+		st:        new(State),
+	}
+	ss.match(newSelectRoot(vi), []selectSegment{{kind: selectSegmentAnyDeep}})
+	return ss.selections
+}
+
+// NewSearchString returns a [SearchPredicate] that matches the string values containing the substring.
+func NewSearchString(substr string) SearchPredicate {
+	return func(v reflect.Value) bool {
+		return v.Kind() == reflect.String && strings.Contains(v.String(), substr)
+	}
+}
+
+// NewSearchRegexp returns a [SearchPredicate] that matches the string values matching the regular expression.
+func NewSearchRegexp(re *regexp.Regexp) SearchPredicate {
+	return func(v reflect.Value) bool {
+		return v.Kind() == reflect.String && re.MatchString(v.String())
+	}
+}
+
+// NewSearchType returns a [SearchPredicate] that matches the values of the type.
+//
+// If the type is an interface, it matches the values implementing it.
+func NewSearchType(typ reflect.Type) SearchPredicate {
+	if typ.Kind() == reflect.Interface {
+		return func(v reflect.Value) bool {
+			return v.IsValid() && v.Type().Implements(typ)
+		}
+	}
+	return func(v reflect.Value) bool {
+		return v.IsValid() && v.Type() == typ
+	}
+}
+
+// NewSearchZero returns a [SearchPredicate] that matches the zero values.
+func NewSearchZero() SearchPredicate {
+	return searchZero
+}
+
+func searchZero(v reflect.Value) bool {
+	return !v.IsValid() || v.IsZero()
+}
+
+// NewSearchNil returns a [SearchPredicate] that matches the nil values (pointer, interface, map, slice, chan, func and unsafe pointer).
+func NewSearchNil() SearchPredicate {
+	return searchNil
+}
+
+func searchNil(v reflect.Value) bool {
+	switch v.Kind() { //nolint:exhaustive // Only handles nillable kinds.
+	case reflect.Invalid:
+		return true
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return v.IsNil()
+	}
+	return false
+}
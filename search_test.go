@@ -0,0 +1,114 @@
+package pretty_test
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/pierrre/assert"
+	"github.com/pierrre/assert/assertauto"
+	. "github.com/pierrre/pretty"
+)
+
+func TestStringSearch(t *testing.T) {
+	v := newTestSearchValue()
+	for _, tc := range []struct {
+		name string
+		pred SearchPredicate
+	}{
+		{"String", NewSearchString("ba")},
+		{"Regexp", NewSearchRegexp(regexp.MustCompile(`^[a-z]{3}$`))},
+		{"Type", NewSearchType(reflect.TypeFor[int]())},
+		{"TypeInterface", NewSearchType(reflect.TypeFor[error]())},
+		{"Zero", NewSearchZero()},
+		{"Nil", NewSearchNil()},
+		{"NoMatch", NewSearchString("missing")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := StringSearch(v, tc.pred)
+			assertauto.Equal(t, s)
+		})
+	}
+}
+
+func TestSearch(t *testing.T) {
+	sels := Search(newTestSearchValue(), NewSearchString("secret"))
+	assert.SliceLen(t, sels, 1)
+	assert.Equal(t, sels[0].Path.String(), ".unexported")
+	assert.Equal(t, sels[0].Value.Interface(), any("secret"))
+}
+
+func TestSearchRecursion(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	n := &node{Name: "test"}
+	n.Next = n
+	sels := Search(n, NewSearchString("test"))
+	assert.SliceLen(t, sels, 1)
+	assert.Equal(t, sels[0].Path.String(), ".Name")
+}
+
+func TestSearchNilRoot(t *testing.T) {
+	sels := Search(nil, NewSearchNil())
+	assert.SliceLen(t, sels, 1)
+	assert.Equal(t, sels[0].Path.String(), "")
+}
+
+func TestPrinterSearchMaxDepth(t *testing.T) {
+	vw := NewCommonWriter()
+	vw.MaxDepth.MaxByPath = map[string]int{
+		".Tags": 0,
+	}
+	p := NewPrinter(vw)
+	sels := p.Search(newTestSearchValue(), NewSearchString("ba"))
+	assert.SliceLen(t, sels, 1)
+	assert.Equal(t, sels[0].Path.String(), `.Labels["a"]`)
+}
+
+func TestPrinterSearchByType(t *testing.T) {
+	vw := NewCommonWriter()
+	vw.ByType[reflect.TypeFor[map[string]string]()] = ValueWriterFunc(func(st *State, v reflect.Value) bool {
+		st.Writer.AppendString("labels")
+		return true
+	})
+	p := NewPrinter(vw)
+	sels := p.Search(newTestSearchValue(), NewSearchString("ba"))
+	assert.SliceLen(t, sels, 2)
+	assert.Equal(t, sels[0].Path.String(), ".Tags[0]")
+	assert.Equal(t, sels[1].Path.String(), ".Tags[2]")
+}
+
+func TestPrinterSearchCanInterfaceDisabled(t *testing.T) {
+	vw := NewCommonWriter()
+	vw.CanInterface = nil
+	p := NewPrinter(vw)
+	sels := p.Search(newTestSearchValue(), NewSearchString("secret"))
+	assert.SliceLen(t, sels, 1)
+	assert.False(t, sels[0].Value.CanInterface())
+}
+
+type testSearchValue struct {
+	Name       string
+	Count      int
+	Tags       []string
+	Labels     map[string]string
+	Pointer    *testSearchValue
+	Err        error
+	Seq        func(yield func(string) bool)
+	unexported string
+}
+
+func newTestSearchValue() testSearchValue {
+	return testSearchValue{
+		Name:       "foo",
+		Tags:       []string{"bar", "", "baz"},
+		Labels:     map[string]string{"a": "bar", "b": "qux"},
+		Err:        errors.New("error"),
+		Seq:        slices.Values([]string{"abc", "xyz"}),
+		unexported: "secret",
+	}
+}
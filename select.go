@@ -0,0 +1,469 @@
+package pretty
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pierrre/go-libs/reflectutil"
+)
+
+// Select returns the values selected by the path with [DefaultSelector].
+func Select(vi any, path string) ([]Selection, error) {
+	return DefaultSelector.Load().Select(vi, path)
+}
+
+// StringPath returns the values selected by the path as a string with [DefaultPrinter].
+func StringPath(vi any, path string) string {
+	return DefaultPrinter.Load().StringPath(vi, path)
+}
+
+// DefaultSelector is the default [Selector].
+//
+// It is created with [NewSelector].
+var DefaultSelector atomic.Pointer[Selector]
+
+func init() {
+	DefaultSelector.Store(NewSelector())
+}
+
+// Selector selects values in a value with a path.
+//
+// The path syntax is:
+//   - "$": the root value (optional)
+//   - ".Name": a struct field
+//   - "[0]": an index in a slice or array
+//   - ["key"]: a map key, quoted for strings, e.g. ["key"] or [123]
+//   - ".*" or "[*]": any child
+//   - ".**" or "[**]": any descendant, at any depth (including the current value)
+//
+// E.g. ".Spec.Containers[0].Env", ".Labels["app"]", ".Items[*].Price" or ".**.Name".
+//
+// Interfaces and pointers are followed transparently.
+// Types with a "Range" [iter.Seq2] method (e.g. [sync.Map]) and [iter.Seq2] are handled like maps, and [iter.Seq] like slices.
+//
+// It should be created with [NewSelector].
+type Selector struct {
+	// Children contains custom functions that range over the children of a value.
+	// They are called before the default behavior.
+	// Default: nil.
+	Children []SelectChildrenFunc
+	// MaxDepth is the max depth of the traversal (the length of the path).
+	// The children of the values at this depth are not visited.
+	// 0 means no limit.
+	// Default: 0.
+	MaxDepth int
+}
+
+// NewSelector creates a new [Selector] with default values.
+func NewSelector() *Selector {
+	return &Selector{
+		Children: nil,
+		MaxDepth: 0,
+	}
+}
+
+// SelectChildrenFunc ranges over the children of a value, and calls yield with the [PathElement] of each child.
+//
+// It stops if yield returns false.
+// It returns false if it doesn't handle the value.
+type SelectChildrenFunc func(v reflect.Value, yield func(e PathElement, child reflect.Value) bool) bool
+
+// Selection is a value selected by a [Selector].
+//
+// The value can be used with [reflect.Value.Interface] if possible, even if it was obtained from an unexported field.
+type Selection struct {
+	Path  Path
+	Value reflect.Value
+}
+
+// Select returns the values selected by the path.
+//
+// It returns an error if the path is invalid.
+func (s *Selector) Select(vi any, path string) ([]Selection, error) {
+	segs, err := parseSelectPath(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", path, err)
+	}
+	ss := &selectState{
+		selector: s,
+	}
+	ss.match(newSelectRoot(vi), segs)
+	return ss.selections, nil
+}
+
+// newSelectRoot returns an addressable copy of the root value, so the values of unexported fields can be converted with [reflectutil.ConvertValueCanInterface].
+func newSelectRoot(vi any) reflect.Value {
+	v := reflect.ValueOf(vi)
+	if !v.IsValid() {
+		return v
+	}
+	root := reflect.New(v.Type()).Elem()
+	root.Set(v)
+	return root
+}
+
+type selectState struct {
+	selector   *Selector
+	predicate  SearchPredicate
+	writer     *CommonWriter // Only used by [Printer.Search].
+	st         *State        // Used by the MaxDepthWriter of the writer.
+	path       Path
+	visited    map[VisitedEntry]struct{}
+	selections []Selection
+	buf        []byte
+}
+
+func (ss *selectState) match(v reflect.Value, segs []selectSegment) {
+	var itfType reflect.Type
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		if itfType == nil {
+			itfType = v.Type() // Used by MaxDepthWriter.MaxByType.
+		}
+		v = v.Elem()
+	}
+	if len(segs) == 0 {
+		if v.IsValid() && (ss.writer == nil || ss.writer.CanInterface != nil) {
+			v, _ = reflectutil.ConvertValueCanInterface(v)
+		}
+		if ss.predicate != nil && !ss.predicate(v) {
+			return
+		}
+		ss.selections = append(ss.selections, Selection{
+			Path:  cloneSelectPath(ss.path),
+			Value: cloneSelectValue(v),
+		})
+		return
+	}
+	seg := segs[0]
+	if seg.kind == selectSegmentAnyDeep {
+		ss.match(v, segs[1:])
+		// Prevents infinite recursion.
+		switch v.Kind() { //nolint:exhaustive // Only handles pointer kinds.
+		case reflect.Pointer, reflect.Map, reflect.Slice:
+			e := VisitedEntry{
+				Type: v.Type(),
+				Addr: uintptr(v.UnsafePointer()),
+			}
+			if _, ok := ss.visited[e]; ok {
+				return
+			}
+			if ss.visited == nil {
+				ss.visited = make(map[VisitedEntry]struct{})
+			}
+			ss.visited[e] = struct{}{}
+			defer delete(ss.visited, e)
+		}
+	}
+	if ss.writer != nil && v.IsValid() {
+		if _, ok := ss.writer.ByType[v.Type()]; ok {
+			return // The value is written by a custom writer, so its children are not written.
+		}
+	}
+	if ss.writer != nil && ss.writer.MaxDepth != nil {
+		st := ss.st
+		st.Path = ss.path
+		st.Depth = len(ss.path)
+		maxReached, previousMax := ss.writer.MaxDepth.enterMaxDepth(st, v, itfType)
+		defer ss.writer.MaxDepth.postMaxDepth(st, previousMax)
+		if maxReached {
+			return
+		}
+	}
+	if ss.selector.MaxDepth > 0 && len(ss.path) >= ss.selector.MaxDepth {
+		return
+	}
+	ss.selector.rangeChildren(v, func(e PathElement, child reflect.Value) bool {
+		switch {
+		case seg.kind == selectSegmentAnyDeep:
+			ss.path = append(ss.path, e)
+			ss.match(child, segs)
+			ss.path = ss.path[:len(ss.path)-1]
+		case ss.matchElement(seg, e):
+			ss.path = append(ss.path, e)
+			ss.match(child, segs[1:])
+			ss.path = ss.path[:len(ss.path)-1]
+		}
+		return true
+	})
+}
+
+// cloneSelectValue returns a copy of a kept value.
+//
+// It is required because the map entries are released after the traversal of the map, and the values inside them are reset.
+// The values that can't be used with [reflect.Value.Interface] are not released, so they are not copied.
+func cloneSelectValue(v reflect.Value) reflect.Value {
+	if !v.IsValid() || !v.CanInterface() {
+		return v
+	}
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+// cloneSelectPath returns a copy of a kept path, with a copy of the map keys (see cloneSelectValue).
+func cloneSelectPath(p Path) Path {
+	p = slices.Clone(p)
+	for i, e := range p {
+		if e.Kind == PathElementKey {
+			p[i].Key = cloneSelectValue(e.Key)
+		}
+	}
+	return p
+}
+
+func (ss *selectState) matchElement(seg selectSegment, e PathElement) bool {
+	switch seg.kind {
+	case selectSegmentAny:
+		return true
+	case selectSegmentField:
+		return e.Kind == PathElementField && e.Name == seg.text
+	case selectSegmentKey:
+		switch e.Kind { //nolint:exhaustive // Only handles indexes and keys.
+		case PathElementIndex:
+			ss.buf = strconv.AppendInt(ss.buf[:0], int64(e.Index), 10)
+		case PathElementKey:
+			ss.buf = appendPathKey(ss.buf[:0], e.Key)
+		default:
+			return false
+		}
+		return string(ss.buf) == seg.text
+	}
+	return false
+}
+
+func (s *Selector) rangeChildren(v reflect.Value, yield func(e PathElement, child reflect.Value) bool) {
+	if !v.IsValid() {
+		return
+	}
+	for {
+		for _, f := range s.Children {
+			if f(v, yield) {
+				return
+			}
+		}
+		if rangeSelectChildrenRange(v, yield) {
+			return
+		}
+		if v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+			break
+		}
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() { //nolint:exhaustive // Other kinds don't have children.
+	case reflect.Struct:
+		reflectutil.GetStructFields(v.Type()).Range(func(i int, field reflect.StructField) bool {
+			return yield(PathElement{Kind: PathElementField, Name: field.Name}, v.Field(i))
+		})
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			if !yield(PathElement{Kind: PathElementIndex, Index: i}, v.Index(i)) {
+				return
+			}
+		}
+	case reflect.Map:
+		es := reflectutil.GetSortedMap(v)
+		defer es.Release() // The kept values are copied, see cloneSelectValue.
+		for _, e := range es {
+			if !yield(PathElement{Kind: PathElementKey, Key: e.Key}, e.Value) {
+				return
+			}
+		}
+	case reflect.Func:
+		rangeSelectChildrenIter(v, yield)
+	}
+}
+
+func (s *Selector) hasChildren(v reflect.Value) bool {
+	has := false
+	s.rangeChildren(v, func(e PathElement, child reflect.Value) bool {
+		has = true
+		return false
+	})
+	return has
+}
+
+func rangeSelectChildrenIter(v reflect.Value, yield func(e PathElement, child reflect.Value) bool) {
+	if v.IsNil() || !v.CanInterface() {
+		return
+	}
+	typ := v.Type()
+	switch {
+	case typ.CanSeq():
+		i := 0
+		for child := range v.Seq() {
+			if !yield(PathElement{Kind: PathElementIndex, Index: i}, child) {
+				return
+			}
+			i++
+		}
+	case typ.CanSeq2():
+		for key, child := range v.Seq2() {
+			if !yield(PathElement{Kind: PathElementKey, Key: key}, child) {
+				return
+			}
+		}
+	}
+}
+
+func rangeSelectChildrenRange(v reflect.Value, yield func(e PathElement, child reflect.Value) bool) bool {
+	m, ok := getRangeMethod(v.Type())
+	if !ok || !v.CanInterface() {
+		return false
+	}
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		return true
+	}
+	m.Func.Call([]reflect.Value{v, reflect.MakeFunc(m.Type.In(1), func(args []reflect.Value) []reflect.Value {
+		if !yield(PathElement{Kind: PathElementKey, Key: args[0]}, args[1]) {
+			return rangeReturnFalse
+		}
+		return rangeReturnTrue
+	})})
+	return true
+}
+
+type selectSegmentKind int
+
+const (
+	selectSegmentField selectSegmentKind = iota
+	selectSegmentKey
+	selectSegmentAny
+	selectSegmentAnyDeep
+)
+
+type selectSegment struct {
+	kind selectSegmentKind
+	text string
+}
+
+func parseSelectPath(s string) ([]selectSegment, error) {
+	s = strings.TrimPrefix(s, "$")
+	if s != "" && s[0] != '.' && s[0] != '[' {
+		s = "." + s // The first field doesn't require a dot.
+	}
+	var segs []selectSegment
+	for s != "" {
+		var seg selectSegment
+		var err error
+		switch s[0] {
+		case '.':
+			seg, s, err = parseSelectField(s[1:])
+		case '[':
+			seg, s, err = parseSelectBracket(s[1:])
+		default:
+			err = fmt.Errorf("unexpected character %q", s[0])
+		}
+		if err != nil {
+			return nil, err
+		}
+		segs = append(segs, seg)
+	}
+	return segs, nil
+}
+
+func parseSelectField(s string) (selectSegment, string, error) {
+	end := strings.IndexAny(s, ".[")
+	if end < 0 {
+		end = len(s)
+	}
+	name := s[:end]
+	seg, ok := parseSelectWildcard(name)
+	if !ok {
+		if name == "" {
+			return selectSegment{}, "", errors.New("empty field name")
+		}
+		seg = selectSegment{kind: selectSegmentField, text: name}
+	}
+	return seg, s[end:], nil
+}
+
+func parseSelectBracket(s string) (selectSegment, string, error) {
+	if strings.HasPrefix(s, `"`) {
+		q, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return selectSegment{}, "", fmt.Errorf("invalid quoted key: %w", err)
+		}
+		if !strings.HasPrefix(s[len(q):], "]") {
+			return selectSegment{}, "", errors.New("missing ']'")
+		}
+		key, _ := strconv.Unquote(q)
+		return selectSegment{kind: selectSegmentKey, text: strconv.Quote(key)}, s[len(q)+1:], nil
+	}
+	end := strings.IndexByte(s, ']')
+	if end < 0 {
+		return selectSegment{}, "", errors.New("missing ']'")
+	}
+	text := strings.TrimSpace(s[:end])
+	seg, ok := parseSelectWildcard(text)
+	if !ok {
+		if text == "" {
+			return selectSegment{}, "", errors.New("empty key")
+		}
+		seg = selectSegment{kind: selectSegmentKey, text: text}
+	}
+	return seg, s[end+1:], nil
+}
+
+func parseSelectWildcard(s string) (selectSegment, bool) {
+	switch s {
+	case "*":
+		return selectSegment{kind: selectSegmentAny}, true
+	case "**":
+		return selectSegment{kind: selectSegmentAnyDeep}, true
+	}
+	return selectSegment{}, false
+}
+
+// StringPath returns the values selected by the path (see [Selector]) as a string.
+//
+// Each value is written on a new line, prefixed by its path.
+// If the path is invalid, it writes the error.
+func (p *Printer) StringPath(vi any, path string) string {
+	st := newState(p)
+	defer st.release()
+	p.writePath(st, vi, path)
+	return st.Writer.String()
+}
+
+func (p *Printer) writePath(st *State, vi any, path string) {
+	sels, err := p.getSelector().Select(vi, path)
+	if err != nil {
+		st.Writer.AppendString("<error: ")
+		st.Writer.AppendString(err.Error())
+		st.Writer.AppendByte('>')
+		return
+	}
+	p.writeSelections(st, sels)
+}
+
+func (p *Printer) getSelector() *Selector {
+	if p.Selector != nil {
+		return p.Selector
+	}
+	return DefaultSelector.Load()
+}
+
+func (p *Printer) writeSelections(st *State, sels []Selection) {
+	for i, sel := range sels {
+		if i > 0 {
+			st.writeNewLine()
+		}
+		st.Path = append(st.Path[:0], sel.Path...) // The annotations contain the full path.
+		st.WriteIndent()
+		st.Writer.AppendByte('$')
+		st.Writer = sel.Path.Append(st.Writer)
+		st.Writer.AppendString(": ")
+		p.writeValue(st, sel.Value)
+		st.trimEmptyGutterLine()
+	}
+	clear(st.Path)
+	st.Path = st.Path[:0]
+}
@@ -0,0 +1,129 @@
+package pretty_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pierrre/assert"
+	"github.com/pierrre/assert/assertauto"
+	. "github.com/pierrre/pretty"
+)
+
+func TestStringPath(t *testing.T) {
+	v := newTestSelectValue()
+	for _, tc := range []struct {
+		name string
+		path string
+	}{
+		{"Empty", ""},
+		{"Root", "$"},
+		{"Field", ".Body.Items[1].Price"},
+		{"NoLeadingDot", "Body.Items[0]"},
+		{"Wildcard", "$.Body.Items[*].Price"},
+		{"QuotedKey", `.Body.Labels["foo"]`},
+		{"WildcardField", ".Body.*"},
+		{"WildcardDeep", ".**.Price"},
+		{"Pointer", ".Pointer.Price"},
+		{"Interface", ".Interface.Price"},
+		{"IntKey", ".Map[123]"},
+		{"Range", `.Range["a"]`},
+		{"NoMatch", ".Missing"},
+		{"ErrorMissingBracket", ".Body["},
+		{"ErrorQuote", `.Body["foo`},
+		{"ErrorEmptyField", "..Body"},
+		{"ErrorCharacter", "[0]?"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := StringPath(v, tc.path)
+			assertauto.Equal(t, s)
+		})
+	}
+}
+
+func TestStringPathAnnotation(t *testing.T) {
+	p := NewPrinter(DefaultWriter.Load())
+	p.PathAnnotation = PathAnnotationComment
+	s := p.StringPath(newTestSelectValue(), ".Body.Items[1]")
+	assertauto.Equal(t, s)
+}
+
+func TestStringPathAnnotationMultiple(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		annotation PathAnnotation
+	}{
+		{"Comment", PathAnnotationComment},
+		{"Gutter", PathAnnotationGutter},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewPrinter(DefaultWriter.Load())
+			p.PathAnnotation = tc.annotation
+			s := p.StringPath(newTestSelectValue(), ".Body.Items[*]")
+			assertauto.Equal(t, s)
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	sels, err := Select(newTestSelectValue(), ".Body.Items[*].Price")
+	assert.NoError(t, err)
+	assert.SliceLen(t, sels, 2)
+	assert.Equal(t, sels[0].Path.String(), ".Body.Items[0].Price")
+	assert.Equal(t, sels[1].Value.Interface(), any(2))
+}
+
+func TestSelectRecursion(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	n := &node{Name: "test"}
+	n.Next = n
+	sels, err := Select(n, ".**.Name")
+	assert.NoError(t, err)
+	assert.SliceLen(t, sels, 2)
+	assert.Equal(t, sels[1].Path.String(), ".Next.Name")
+}
+
+func TestSelectMap(t *testing.T) {
+	type entry struct {
+		Value int
+	}
+	m := map[string]entry{
+		"a": {Value: 1},
+		"b": {Value: 2},
+	}
+	sels, err := Select(m, ".*.Value")
+	assert.NoError(t, err)
+	_, err = Select(m, ".*.Value") // Reuses the released map entries.
+	assert.NoError(t, err)
+	assert.SliceLen(t, sels, 2)
+	assert.Equal(t, sels[1].Path.String(), `["b"].Value`)
+	assert.Equal(t, sels[1].Value.Interface(), any(2))
+}
+
+func TestSelectError(t *testing.T) {
+	_, err := Select(nil, ".Body[")
+	assert.Error(t, err)
+}
+
+type testSelectValue struct {
+	Body      testPathBody
+	Pointer   **testPathItem
+	Interface any
+	Map       map[int]string
+	Range     *sync.Map
+}
+
+func newTestSelectValue() testSelectValue {
+	item := &testPathItem{Price: 3}
+	rm := new(sync.Map)
+	rm.Store("a", 1)
+	return testSelectValue{
+		Body:      newTestPathValue().Body,
+		Pointer:   &item,
+		Interface: testPathItem{Price: 4},
+		Map:       map[int]string{123: "test"},
+		Range:     rm,
+	}
+}
@@ -0,0 +1,344 @@
+package pretty
+
+import (
+	"math/bits"
+	"reflect"
+	"strconv"
+
+	"github.com/pierrre/go-libs/reflectutil"
+	"github.com/pierrre/go-libs/syncutil"
+)
+
+// Size returns the deep size of a value in memory, in bytes.
+//
+// It includes the size of the value itself (including struct padding), and the size of the data it references:
+//   - pointers: the size of the pointed value
+//   - slices: the capacity of the backing array
+//   - strings: the length of the data
+//   - maps: an estimate of the buckets/groups overhead, and the size of the keys and values
+//   - channels: the buffer and an estimate of the header
+//   - interfaces: the boxed value
+//
+// The data shared by several values (pointers, slices backing arrays, maps, channels, strings data) is counted once, with [VisitedEntry] as identity.
+// It also prevents infinite recursion.
+//
+// The size of functions (closures) is not counted.
+// The result is an estimate, the actual memory usage depends on the runtime (e.g. allocation size classes).
+func Size(vi any) int {
+	return sizeOf(reflect.ValueOf(vi))
+}
+
+func sizeOf(v reflect.Value) int {
+	if !v.IsValid() {
+		return 0
+	}
+	ss := sizeStatePool.Get()
+	defer sizeStatePool.Put(ss)
+	clear(ss.visited)
+	return ss.size(v)
+}
+
+type sizeState struct {
+	visited map[VisitedEntry]struct{}
+	// cached contains the size of the data referenced by the visited entries.
+	// It is nil if the sizes are not cached.
+	cached map[VisitedEntry]int
+	// counted contains the entries counted by the current computation, if the sizes are cached.
+	counted map[VisitedEntry]struct{}
+}
+
+var sizeStatePool = syncutil.Pool[*sizeState]{
+	New: func() *sizeState {
+		return &sizeState{
+			visited: make(map[VisitedEntry]struct{}),
+		}
+	},
+}
+
+// cachedSize returns the size of the value, and caches the size of the data it references.
+//
+// The first computation visits all the data (e.g. the root value).
+// The next computations (e.g. the children) use the cached sizes, instead of visiting the data again.
+// The data shared by several values is counted in the first visited one (the map entries are visited sorted by key).
+func (ss *sizeState) cachedSize(v reflect.Value) int {
+	if !v.IsValid() {
+		return 0
+	}
+	if ss.cached == nil {
+		ss.visited = make(map[VisitedEntry]struct{})
+		ss.cached = make(map[VisitedEntry]int)
+		ss.counted = make(map[VisitedEntry]struct{})
+	}
+	clear(ss.counted)
+	return ss.size(v)
+}
+
+func (ss *sizeState) reset() {
+	clear(ss.visited)
+	clear(ss.cached)
+	clear(ss.counted)
+}
+
+// size returns the size of the value itself and the size of the data it references.
+func (ss *sizeState) size(v reflect.Value) int {
+	return int(v.Type().Size()) + ss.external(v)
+}
+
+// external returns the size of the data referenced by the value.
+//
+//nolint:gocyclo // We need to handle all kinds.
+func (ss *sizeState) external(v reflect.Value) int {
+	switch v.Kind() { //nolint:exhaustive // Other kinds don't reference data.
+	case reflect.String:
+		if v.Len() == 0 {
+			return 0
+		}
+		e := newSizeEntry(v)
+		if n, ok := ss.lookup(e); ok {
+			return n
+		}
+		return ss.store(e, v.Len())
+	case reflect.Pointer:
+		if v.IsNil() {
+			return 0
+		}
+		e := newSizeEntry(v)
+		if n, ok := ss.lookup(e); ok {
+			return n
+		}
+		return ss.store(e, ss.size(v.Elem()))
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		e := v.Elem()
+		if isSizeDirectInterface(e.Type()) {
+			return ss.external(e)
+		}
+		return ss.size(e) // The value is boxed.
+	case reflect.Slice:
+		if v.IsNil() {
+			return 0
+		}
+		e := newSizeEntry(v)
+		if n, ok := ss.lookup(e); ok {
+			return n
+		}
+		n := v.Cap() * int(v.Type().Elem().Size())
+		return ss.store(e, n+ss.externalElems(v))
+	case reflect.Array:
+		return ss.externalElems(v)
+	case reflect.Struct:
+		n := 0
+		for i := range v.NumField() {
+			if typeHasPointers(v.Type().Field(i).Type) {
+				n += ss.external(v.Field(i))
+			}
+		}
+		return n
+	case reflect.Map:
+		if v.IsNil() {
+			return 0
+		}
+		e := newSizeEntry(v)
+		if n, ok := ss.lookup(e); ok {
+			return n
+		}
+		typ := v.Type()
+		n := estimateMapSize(typ, v.Len())
+		if typeHasPointers(typ.Key()) || typeHasPointers(typ.Elem()) {
+			es := reflectutil.GetSortedMap(v) // The shared data is counted in the first visited entry, so the order must be deterministic.
+			defer es.Release()
+			for _, e := range es {
+				n += ss.external(e.Key) + ss.external(e.Value)
+			}
+		}
+		return ss.store(e, n)
+	case reflect.Chan:
+		if v.IsNil() {
+			return 0
+		}
+		e := newSizeEntry(v)
+		if n, ok := ss.lookup(e); ok {
+			return n
+		}
+		return ss.store(e, chanHeaderSize+v.Cap()*int(v.Type().Elem().Size()))
+	}
+	return 0
+}
+
+func (ss *sizeState) externalElems(v reflect.Value) int {
+	if !typeHasPointers(v.Type().Elem()) {
+		return 0
+	}
+	n := 0
+	for i := range v.Len() {
+		n += ss.external(v.Index(i))
+	}
+	return n
+}
+
+func newSizeEntry(v reflect.Value) VisitedEntry {
+	return VisitedEntry{
+		Type: v.Type(),
+		Addr: v.Pointer(),
+	}
+}
+
+// lookup returns the size of the data referenced by the entry, and true if it was already visited.
+//
+// If it returns false, the size must be computed and stored with [sizeState.store].
+func (ss *sizeState) lookup(e VisitedEntry) (int, bool) {
+	if _, ok := ss.visited[e]; !ok {
+		ss.visited[e] = struct{}{}
+		return 0, false
+	}
+	if ss.cached == nil {
+		return 0, true // The data is counted once.
+	}
+	if _, ok := ss.counted[e]; ok {
+		return 0, true // The data is counted once in the current computation.
+	}
+	ss.counted[e] = struct{}{}
+	return ss.cached[e], true
+}
+
+// store stores the size of the data referenced by the entry, and returns it.
+func (ss *sizeState) store(e VisitedEntry, n int) int {
+	if ss.cached != nil {
+		ss.cached[e] = n
+		ss.counted[e] = struct{}{}
+	}
+	return n
+}
+
+// isSizeDirectInterface returns true if the values of the type are stored directly in an interface, without allocation.
+func isSizeDirectInterface(typ reflect.Type) bool {
+	switch typ.Kind() { //nolint:exhaustive // Other kinds are boxed.
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return true
+	}
+	return typ.Size() == 0
+}
+
+// typeHasPointers returns true if the values of the type can reference data.
+func typeHasPointers(typ reflect.Type) bool {
+	switch typ.Kind() { //nolint:exhaustive // Other kinds have pointers.
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return typ.Len() > 0 && typeHasPointers(typ.Elem())
+	case reflect.Struct:
+		for i := range typ.NumField() {
+			if typeHasPointers(typ.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+const (
+	mapHeaderSize  = 48
+	mapGroupSlots  = 8
+	chanHeaderSize = 96
+)
+
+// estimateMapSize returns an estimate of the memory used by a map, excluding the data referenced by the keys and values.
+//
+// It is based on the groups of 8 slots with a control word used by the runtime.
+// The max load factor is 7/8, and the capacity is a power of 2.
+func estimateMapSize(typ reflect.Type, l int) int {
+	slots := mapGroupSlots
+	if l > mapGroupSlots {
+		slots = 1 << bits.Len(uint((l*8+6)/7-1))
+	}
+	slotSize := int(typ.Key().Size() + typ.Elem().Size())
+	return mapHeaderSize + slots/mapGroupSlots*8 + slots*slotSize
+}
+
+var sizeUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// appendSize appends a human readable size in bytes, e.g. "123B" or "1.2KiB".
+func appendSize(dst []byte, n int64) []byte {
+	if n < 1024 && n > -1024 {
+		dst = strconv.AppendInt(dst, n, 10)
+		return append(dst, 'B')
+	}
+	f := float64(n)
+	unit := ""
+	for _, unit = range sizeUnits {
+		f /= 1024
+		if f < 1024 && f > -1024 {
+			break
+		}
+	}
+	start := len(dst)
+	dst = strconv.AppendFloat(dst, f, 'f', 1, 64)
+	if l := len(dst); dst[l-1] == '0' && l-start > 2 {
+		dst = dst[:l-2] // Removes ".0".
+	}
+	return append(dst, unit...)
+}
+
+// SizeWriter is a [ValueWriter] that writes the deep size of the value (see [Size]), e.g. "(size=1.2KiB)".
+//
+// The size is written before the value, and it is merged with the infos (e.g. "(size=1.2KiB len=3)").
+// The sizes are computed once from the root value, and the sizes of the referenced data are cached for the children.
+// The data shared by several values is counted in the first visited one (the map entries are visited sorted by key).
+//
+// It should be created with [NewSizeWriter].
+type SizeWriter struct {
+	ValueWriter
+}
+
+// NewSizeWriter creates a new [SizeWriter].
+func NewSizeWriter(vw ValueWriter) *SizeWriter {
+	return &SizeWriter{
+		ValueWriter: vw,
+	}
+}
+
+// WriteValue implements [ValueWriter].
+func (vw *SizeWriter) WriteValue(st *State, v reflect.Value) bool {
+	previous := vw.writeSize(st, v)
+	ok := vw.ValueWriter.WriteValue(st, v)
+	vw.postSize(st, previous, ok)
+	return ok
+}
+
+// writeSize writes the size before the value, e.g. "(size=1.2KiB) ".
+// It is merged with the infos of the value if they are written right after it (see [infos.write]).
+//
+// It returns the previous size, that must be restored with [SizeWriter.postSize].
+func (vw *SizeWriter) writeSize(st *State, v reflect.Value) (previous stateSize) {
+	previous = st.size
+	st.size = stateSize{}
+	if !st.ShowInfos {
+		return previous
+	}
+	start := len(st.Writer)
+	st.Writer = appendSizeInfos(st.Writer, st.sizes.cachedSize(v))
+	st.Writer.AppendString(") ")
+	st.size = stateSize{
+		start:   start,
+		end:     len(st.Writer),
+		pending: true,
+	}
+	return previous
+}
+
+// postSize removes the size if the value was not written, and restores the previous size.
+func (vw *SizeWriter) postSize(st *State, previous stateSize, written bool) {
+	if !written && st.size.pending && st.size.end == len(st.Writer) {
+		st.Writer = st.Writer[:st.size.start]
+	}
+	st.size = previous
+}
+
+// appendSizeInfos appends the start of the infos with the size, e.g. "(size=1.2KiB".
+func appendSizeInfos(dst []byte, size int) []byte {
+	dst = append(dst, "(size="...)
+	return appendSize(dst, int64(size))
+}
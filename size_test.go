@@ -0,0 +1,134 @@
+package pretty_test
+
+import (
+	"testing"
+
+	"github.com/pierrre/assert"
+	. "github.com/pierrre/pretty"
+	"github.com/pierrre/pretty/internal/prettytest"
+)
+
+func init() {
+	prettytest.AddCasesPrefix("Size", []*prettytest.Case{
+		{
+			Name:            "Default",
+			Value:           newTestSizeValue(),
+			ConfigureWriter: configureTestSize,
+		},
+		{
+			Name:  "ShowCap",
+			Value: make([]int, 2, 100),
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestSize(vw)
+				vw.SetShowCap(true)
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Large",
+			Value:           make([]int64, 0, 200),
+			ConfigureWriter: configureTestSize,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Compact",
+			Value: newTestSizeValue(),
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: configureTestSize,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "Shared",
+			Value: func() any {
+				s := []string{"a", "bb"}
+				return [][]string{s, s, s[:1]}
+			}(),
+			ConfigureWriter: configureTestSize,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "SharedMap",
+			Value: func() any {
+				s := "shared"
+				return map[string][]string{"a": {s}, "b": {s}, "c": {s}, "d": {s}}
+			}(),
+			ConfigureWriter: configureTestSize,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Writer",
+			Value: []string{"abc"},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.ValueWriters = ValueWriters{NewSizeWriter(vw.Kind.Slice)}
+			},
+			IgnoreBenchmark: true,
+		},
+	})
+}
+
+type testSizeValue struct {
+	Bool   bool
+	Int    int
+	String string
+	Slice  []string
+	Map    map[string]int
+}
+
+func newTestSizeValue() *testSizeValue {
+	return &testSizeValue{
+		Bool:   true,
+		Int:    123,
+		String: "test",
+		Slice:  []string{"a", "bb"},
+		Map:    map[string]int{"a": 1},
+	}
+}
+
+func configureTestSize(vw *CommonWriter) {
+	vw.Size = NewSizeWriter(nil)
+}
+
+func TestSize(t *testing.T) {
+	s := "test"
+	p := &s
+	type recursive struct {
+		Next *recursive
+	}
+	r := &recursive{}
+	r.Next = r
+	for _, tc := range []struct {
+		name     string
+		value    any
+		expected int
+	}{
+		{"Nil", nil, 0},
+		{"Int", 123, 8},
+		{"String", "test", 16 + 4},
+		{"SliceCap", make([]int32, 1, 10), 24 + 40},
+		{"Pointer", p, 8 + 16 + 4},
+		{"SharedString", []string{s, s}, 24 + 2*16 + 4},
+		{"SharedPointer", []*string{p, p}, 24 + 2*8 + 16 + 4},
+		{"Padding", struct {
+			A bool
+			B int64
+		}{}, 16},
+		{"Interface", []any{int64(1)}, 24 + 16 + 8},
+		{"Recursive", r, 8 + 8},
+		{"Chan", make(chan int64, 2), 8 + 96 + 16},
+		{"Map", map[int64]int64{1: 2}, 8 + 48 + 8 + 8*16},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, Size(tc.value), tc.expected)
+		})
+	}
+}
+
+func TestSizeLarge(t *testing.T) {
+	m := make(map[int]int)
+	for i := range 100 {
+		m[i] = i
+	}
+	assert.Equal(t, Size(m), 8+48+128/8*8+128*16)
+}
@@ -22,19 +22,31 @@ type SliceWriter struct {
 	// Default: false.
 	ShowIndexes bool
 	// MaxLen is the maximum length of the slice.
+	// If the slice exceeds this length, it is truncated (see Truncate).
 	// Default: 0 (no limit).
 	MaxLen int
+	// Truncate is the mode used to truncate the slice.
+	// Default: [TruncateModeHead].
+	Truncate TruncateMode
+	// CollapseRepeated collapses the consecutive equal elements.
+	// The first element is written, followed by `<repeated N times>` for the N next elements.
+	// The elements are compared before they are written, as with the == operator, and the pointers are compared by their target.
+	// The elements that are not comparable (e.g. slices, maps) are never collapsed.
+	// Default: false.
+	CollapseRepeated bool
 }
 
 // NewSliceWriter creates a new [SliceWriter] with default values.
 func NewSliceWriter(vw ValueWriter) *SliceWriter {
 	return &SliceWriter{
-		ValueWriter: vw,
-		ShowLen:     true,
-		ShowCap:     true,
-		ShowAddr:    false,
-		ShowIndexes: false,
-		MaxLen:      0,
+		ValueWriter:      vw,
+		ShowLen:          true,
+		ShowCap:          true,
+		ShowAddr:         false,
+		ShowIndexes:      false,
+		MaxLen:           0,
+		Truncate:         TruncateModeHead,
+		CollapseRepeated: false,
 	}
 }
 
@@ -54,7 +66,7 @@ func (vw *SliceWriter) WriteValue(st *State, v reflect.Value) bool {
 		showAddr: vw.ShowAddr,
 		addr:     uintptr(v.UnsafePointer()),
 	}.writeWithTrailingSpace(st)
-	writeArray(st, v, vw.ShowIndexes, vw.MaxLen, vw.ValueWriter)
+	writeArray(st, v, vw.ShowIndexes, vw.MaxLen, vw.Truncate, vw.CollapseRepeated, vw.ValueWriter)
 	return true
 }
 
@@ -1,6 +1,8 @@
 package pretty_test
 
 import (
+	"math"
+
 	. "github.com/pierrre/pretty"
 	"github.com/pierrre/pretty/internal/prettytest"
 )
@@ -54,6 +56,110 @@ func init() {
 			},
 			IgnoreBenchmark: true,
 		},
+		{
+			Name:  "TruncateMiddle",
+			Value: []int{1, 2, 3, 4, 5, 6, 7},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Slice.MaxLen = 3
+				vw.Kind.Slice.Truncate = TruncateModeMiddle
+				vw.Kind.Slice.ShowIndexes = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "TruncateMiddleCompact",
+			Value: []int{1, 2, 3, 4, 5, 6, 7},
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Slice.MaxLen = 4
+				vw.Kind.Slice.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "CollapseRepeated",
+			Value: []float64{1.5, 0, 0, 0, 0, 2.5, 2.5, 3.5, 0, 0, 0},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Slice.CollapseRepeated = true
+			},
+		},
+		{
+			Name:  "CollapseRepeatedShowIndexes",
+			Value: []string{"a", "a", "a", "b"},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Slice.CollapseRepeated = true
+				vw.Kind.Slice.ShowIndexes = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "CollapseRepeatedCompact",
+			Value: []int{1, 0, 0, 0, 2, 0, 0},
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Slice.CollapseRepeated = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "CollapseRepeatedTruncateMiddle",
+			Value: []int{0, 0, 0, 1, 2, 3, 4, 4, 4},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Slice.CollapseRepeated = true
+				vw.Kind.Slice.MaxLen = 6
+				vw.Kind.Slice.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "CollapseRepeatedStructs",
+			Value: []*testCollapseRepeated{
+				{A: 1, B: "a"},
+				{A: 1, B: "a"},
+				{A: 1, B: "a"},
+				{A: 2, B: "b"},
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Slice.CollapseRepeated = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "CollapseRepeatedPathAnnotation",
+			Value: []testCollapseRepeated{
+				{A: 1, B: "a"},
+				{A: 1, B: "a"},
+				{A: 1, B: "a"},
+				{A: 2, B: "b"},
+			},
+			ConfigurePrinter: func(p *Printer) {
+				p.PathAnnotation = PathAnnotationComment
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Slice.CollapseRepeated = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "CollapseRepeatedNotComparable",
+			Value: [][]int{{1}, {1}, {1}},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Slice.CollapseRepeated = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "CollapseRepeatedFloatSign",
+			Value: []float64{0, math.Copysign(0, -1), 0, 0, 0},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Slice.CollapseRepeated = true
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:            "UnknownType",
 			Value:           []any{1, 2, 3},
@@ -84,3 +190,8 @@ func init() {
 		},
 	})
 }
+
+type testCollapseRepeated struct {
+	A int
+	B string
+}
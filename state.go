@@ -1,7 +1,10 @@
 package pretty
 
 import (
+	"bytes"
 	"reflect"
+	"slices"
+	"unicode/utf8"
 
 	"github.com/pierrre/go-libs/bytesutil"
 	"github.com/pierrre/go-libs/syncutil"
@@ -22,6 +25,56 @@ type State struct {
 	Visited      map[VisitedEntry]struct{}
 	KnownType    bool
 	ShowInfos    bool
+	// Compact writes the value on a single line.
+	// See [Printer.Compact].
+	Compact bool
+	// Path is the path of the current value.
+	// It is updated with [State.PushPath] and [State.PopPath].
+	Path Path
+	// PathAnnotation annotates lines with their path.
+	// See [Printer.PathAnnotation].
+	PathAnnotation PathAnnotation
+	// PathGutterWidth is the minimum width of the gutter.
+	// See [Printer.PathGutterWidth].
+	PathGutterWidth int
+	// SymbolicAddr writes symbolic tokens instead of the real addresses.
+	// See [Printer.SymbolicAddr].
+	SymbolicAddr bool
+
+	linePathLen int
+	linePrefix  []byte
+	alignItems  []alignItem
+	references  map[referenceEntry]int
+	reference   referenceLabel
+	addrs       map[uintptr]int
+	maxDepth    int
+	pathBuffer  []byte
+	size        stateSize
+	sizes       sizeState
+	structField structFieldHint
+
+	// visitedPathLens contains the length of [State.Path] when the [State.Visited] values were visited.
+	visitedPathLens map[VisitedEntry]int
+}
+
+// stateSize is the size of the current value, set by [SizeWriter].
+type stateSize struct {
+	// start and end are the position of the size in the writer.
+	start int
+	end   int
+	// pending is true if the size is not merged with the infos yet.
+	pending bool
+}
+
+// structFieldHint is the struct field of the value being written.
+//
+// It is set by [StructWriter], and it is used by [UnitWriter].
+type structFieldHint struct {
+	// typ is the type of the struct.
+	typ   reflect.Type
+	index int
+	// pathLen is the length of [State.Path] of the field value, or 0 if there is no field.
+	pathLen int
 }
 
 var statePool = syncutil.Pool[*State]{
@@ -30,22 +83,219 @@ var statePool = syncutil.Pool[*State]{
 	},
 }
 
-func newState(indentString string) *State {
+func newState(p *Printer) *State {
 	st := statePool.Get()
 	st.Depth = 0
-	st.IndentString = indentString
+	st.maxDepth = 0
+	st.IndentString = p.Indent
 	st.IndentLevel = 0
 	clear(st.Visited)
+	clear(st.visitedPathLens)
+	clear(st.references)
+	st.reference = referenceLabel{}
 	st.KnownType = false
 	st.ShowInfos = true
+	st.Compact = p.Compact
+	st.Path = st.Path[:0]
+	st.PathAnnotation = p.PathAnnotation
+	if p.Compact {
+		st.PathAnnotation = PathAnnotationNone
+	}
+	st.PathGutterWidth = p.PathGutterWidth
+	st.SymbolicAddr = p.SymbolicAddr
+	clear(st.addrs)
+	st.linePathLen = 0
+	st.alignItems = st.alignItems[:0]
+	st.size = stateSize{}
+	st.sizes.reset()
+	st.structField = structFieldHint{}
 	return st
 }
 
 // WriteIndent writes the current indentation to the writer.
+//
+// It must be called at the beginning of a line.
 func (st *State) WriteIndent() {
+	st.linePathLen = len(st.Path)
+	if st.PathAnnotation == PathAnnotationGutter {
+		st.Writer = st.appendPathGutter(st.Writer)
+	}
 	st.Writer = indent.Append(st.Writer, st.IndentString, st.IndentLevel)
 }
 
+// PushPath pushes a [PathElement] to [State.Path].
+//
+// Block items must be pushed before calling [State.WriteBlockItemStart], and popped after calling [State.WriteBlockItemEnd].
+func (st *State) PushPath(e PathElement) {
+	st.Path = append(st.Path, e)
+}
+
+// PopPath pops the last [PathElement] from [State.Path].
+func (st *State) PopPath() {
+	l := len(st.Path) - 1
+	st.Path[l] = PathElement{} // Don't retain the key.
+	st.Path = st.Path[:l]
+}
+
+func (st *State) appendPathGutter(dst []byte) []byte {
+	start := len(dst)
+	dst = st.Path.Append(dst)
+	for range st.PathGutterWidth - (len(dst) - start) {
+		dst = append(dst, ' ')
+	}
+	return append(dst, " | "...)
+}
+
+// trimEmptyGutterLine removes the last line if it only contains the gutter of the current path.
+//
+// It happens if a value ends with a new line (e.g. a hex dump) and nothing is written after it.
+func (st *State) trimEmptyGutterLine() {
+	if st.PathAnnotation != PathAnnotationGutter {
+		return
+	}
+	st.linePrefix = st.appendPathGutter(append(st.linePrefix[:0], '\n'))
+	if bytes.HasSuffix(st.Writer, st.linePrefix) {
+		st.Writer = st.Writer[:len(st.Writer)-len(st.linePrefix)+1]
+	}
+}
+
+// writeNewLine writes a new line, preceded by the path of the line with [PathAnnotationComment].
+func (st *State) writeNewLine() {
+	if st.PathAnnotation == PathAnnotationComment {
+		p := st.Path[:min(st.linePathLen, len(st.Path))]
+		if len(p) > 0 {
+			st.Writer.AppendString(" // ")
+			st.Writer = p.Append(st.Writer)
+		}
+	}
+	st.Writer.AppendByte('\n')
+}
+
+// newIndentWriter returns an [indent.Writer] that writes lines at the current indentation level.
+//
+// It must be released after use.
+func (st *State) newIndentWriter() *indent.Writer {
+	if st.PathAnnotation != PathAnnotationGutter {
+		return indent.NewWriter(&st.Writer, st.IndentString, st.IndentLevel, false)
+	}
+	st.linePrefix = st.appendPathGutter(st.linePrefix[:0])
+	st.linePrefix = indent.Append(st.linePrefix, st.IndentString, st.IndentLevel)
+	return indent.NewWriterPrefix(&st.Writer, st.linePrefix, false)
+}
+
+// WriteBlockItemStart writes the start of an item in a block (e.g. a struct field, a slice element).
+//
+// The first item starts with a new line, and each item starts with the indentation.
+// In compact mode, the items are separated with ", ".
+func (st *State) WriteBlockItemStart(first bool) {
+	if st.Compact {
+		if !first {
+			st.Writer.AppendString(", ")
+		}
+		return
+	}
+	if first {
+		st.writeNewLine()
+	}
+	st.WriteIndent()
+}
+
+// WriteBlockItemEnd writes the end of an item in a block.
+//
+// In compact mode, it doesn't write anything.
+func (st *State) WriteBlockItemEnd() {
+	if st.Compact {
+		return
+	}
+	st.Writer.AppendByte(',')
+	st.writeNewLine()
+}
+
+// WriteBlockEnd writes the end of a block, before the closing delimiter.
+//
+// The hasItems argument indicates whether the block contains at least one item.
+// In compact mode, it doesn't write anything.
+func (st *State) WriteBlockEnd(hasItems bool) {
+	if st.Compact || !hasItems {
+		return
+	}
+	st.WriteIndent()
+}
+
+// AlignBlockStart starts the alignment of the values in a block (e.g. struct fields, map entries).
+//
+// It returns a value that must be passed to [State.AlignBlockEnd].
+func (st *State) AlignBlockStart() int {
+	return len(st.alignItems)
+}
+
+// AlignBlockItem marks the start of the value of an item in an aligned block.
+//
+// It must be called after the key and its separator (e.g. ": ") are written.
+// The keyStart argument is the position of the key in the writer.
+// In compact mode, it doesn't do anything.
+func (st *State) AlignBlockItem(keyStart int) {
+	if st.Compact {
+		return
+	}
+	st.alignItems = append(st.alignItems, alignItem{
+		keyStart:   keyStart,
+		valueStart: len(st.Writer),
+	})
+}
+
+// AlignBlockEnd ends the alignment of the values in a block.
+//
+// It pads the keys (measured in the writer), so all the values start at the same column.
+// Multi-line keys are not aligned.
+func (st *State) AlignBlockEnd(start int) {
+	items := st.alignItems[start:]
+	defer func() {
+		st.alignItems = st.alignItems[:start]
+	}()
+	maxWidth := 0
+	for i, item := range items {
+		key := st.Writer[item.keyStart:item.valueStart]
+		width := -1
+		if bytes.IndexByte(key, '\n') < 0 {
+			width = utf8.RuneCount(key)
+		}
+		items[i].width = width
+		maxWidth = max(maxWidth, width)
+	}
+	padding := 0
+	for _, item := range items {
+		if item.width >= 0 {
+			padding += maxWidth - item.width
+		}
+	}
+	if padding == 0 {
+		return
+	}
+	// Inserts the padding from the end, so each byte is moved once.
+	end := len(st.Writer)
+	st.Writer = slices.Grow(st.Writer, padding)[:end+padding]
+	dst := end + padding
+	for i := len(items) - 1; i >= 0; i-- {
+		item := items[i]
+		if item.width < 0 {
+			continue
+		}
+		dst -= copy(st.Writer[dst-(end-item.valueStart):dst], st.Writer[item.valueStart:end])
+		end = item.valueStart
+		for range maxWidth - item.width {
+			dst--
+			st.Writer[dst] = ' '
+		}
+	}
+}
+
+type alignItem struct {
+	keyStart   int
+	valueStart int
+	width      int
+}
+
 func (st *State) release() {
 	st.Writer.Reset()
 	statePool.Put(st)
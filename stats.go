@@ -0,0 +1,239 @@
+package pretty
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+
+	"github.com/pierrre/go-libs/reflectutil"
+)
+
+// Stats returns statistics about a value with [DefaultPrinter].
+func Stats(vi any) *ValueStats {
+	return DefaultPrinter.Load().Stats(vi)
+}
+
+// StringStats returns statistics about a value as a string with [DefaultPrinter].
+func StringStats(vi any) string {
+	return DefaultPrinter.Load().StringStats(vi)
+}
+
+// ValueStats contains statistics about a value.
+//
+// It is returned by [Selector.Stats].
+type ValueStats struct {
+	// Nodes is the number of visited values.
+	Nodes int
+	// MaxDepth is the max depth reached.
+	MaxDepth int
+	// Nils is the number of nil values (pointer, interface, map, slice, chan and func).
+	Nils int
+	// Errors is the number of non-nil error values.
+	Errors int
+	// Recursions is the number of values that were not visited again, because they were already visited in the current path.
+	Recursions int
+	// MaxDepthReached is the number of values whose children were not visited, because [Selector.MaxDepth] or the limits of [MaxDepthWriter] were reached.
+	MaxDepthReached int
+	// Types contains the number of values by type, sorted by count (descending).
+	Types []ValueStatsType
+	// Largest contains the largest slices, maps and strings, sorted by length (descending).
+	Largest []ValueStatsLargest
+}
+
+// ValueStatsType is the number of values of a type in [ValueStats].
+type ValueStatsType struct {
+	Type  string
+	Count int
+}
+
+// ValueStatsLargest is a large value in [ValueStats].
+type ValueStatsLargest struct {
+	Path string
+	Type string
+	Len  int
+}
+
+const statsLargestCount = 10
+
+// Stats returns statistics about a value.
+//
+// It uses the same traversal as [Selector.Select] with the path ".**", but it doesn't keep the values.
+// The recursive values are detected with [State.Visited], and they are not visited again.
+func (s *Selector) Stats(vi any) *ValueStats {
+	return s.stats(vi, nil)
+}
+
+// Stats returns statistics about a value (see [Selector.Stats]).
+//
+// It uses the [Selector] of the [Printer].
+// If the [ValueWriter] is a [CommonWriter], the limits of its [MaxDepthWriter] (Max, MaxByType and MaxByPath) are applied.
+// The depth is the length of the [Path] of the value, so the pointers don't increase it.
+func (p *Printer) Stats(vi any) *ValueStats {
+	var md *MaxDepthWriter
+	if vw, ok := p.ValueWriter.(*CommonWriter); ok {
+		md = vw.MaxDepth
+	}
+	return p.getSelector().stats(vi, md)
+}
+
+// StringStats returns statistics about a value (see [Printer.Stats]) as a string.
+func (p *Printer) StringStats(vi any) string {
+	return p.String(p.Stats(vi))
+}
+
+func (s *Selector) stats(vi any, md *MaxDepthWriter) *ValueStats {
+	ss := &statsState{
+		selector: s,
+		maxDepth: md,
+		st:       new(State),
+		types:    make(map[reflect.Type]int),
+	}
+	ss.visit(newSelectRoot(vi))
+	stats := &ValueStats{
+		Nodes:           ss.nodes,
+		MaxDepth:        ss.depth,
+		Nils:            ss.nils,
+		Errors:          ss.errors,
+		Recursions:      ss.recursions,
+		MaxDepthReached: ss.maxDepthReached,
+		Types:           make([]ValueStatsType, 0, len(ss.types)),
+		Largest:         make([]ValueStatsLargest, 0, len(ss.largest)),
+	}
+	for typ, count := range ss.types {
+		stats.Types = append(stats.Types, ValueStatsType{
+			Type:  reflectutil.TypeFullName(typ),
+			Count: count,
+		})
+	}
+	slices.SortFunc(stats.Types, func(a, b ValueStatsType) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Type, b.Type))
+	})
+	for _, l := range ss.largest {
+		stats.Largest = append(stats.Largest, ValueStatsLargest{
+			Path: "$" + l.path.String(),
+			Type: reflectutil.TypeFullName(l.typ),
+			Len:  l.len,
+		})
+	}
+	return stats
+}
+
+// statsState is the state of the traversal of [Selector.Stats].
+//
+// The [State] contains the path, the visited values and the depth, as when the value is written.
+type statsState struct {
+	selector        *Selector
+	maxDepth        *MaxDepthWriter
+	st              *State
+	nodes           int
+	depth           int
+	nils            int
+	errors          int
+	recursions      int
+	maxDepthReached int
+	types           map[reflect.Type]int
+	largest         []statsLargest
+}
+
+type statsLargest struct {
+	path Path
+	typ  reflect.Type
+	len  int
+}
+
+func (ss *statsState) visit(v reflect.Value) {
+	var itfType reflect.Type
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		if itfType == nil {
+			itfType = v.Type() // Used by MaxDepthWriter.MaxByType.
+		}
+		v = v.Elem()
+	}
+	ss.nodes++
+	if !v.IsValid() {
+		ss.nils++
+		return
+	}
+	st := ss.st
+	ss.depth = max(ss.depth, len(st.Path))
+	typ := v.Type()
+	ss.types[typ]++
+	switch v.Kind() { //nolint:exhaustive // Only handles nillable and sized kinds.
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		if v.IsNil() {
+			ss.nils++
+			return
+		}
+	}
+	if errorImplementsCache.ImplementedBy(typ) {
+		ss.errors++
+	}
+	switch v.Kind() { //nolint:exhaustive // Only handles kinds with a length.
+	case reflect.Slice, reflect.Map, reflect.String:
+		ss.addLargest(typ, v.Len())
+	}
+	switch v.Kind() { //nolint:exhaustive // Only handles pointer kinds, as RecursionWriter.
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		e := VisitedEntry{
+			Type: typ,
+			Addr: uintptr(v.UnsafePointer()),
+		}
+		if _, ok := st.Visited[e]; ok {
+			ss.recursions++
+			return
+		}
+		if st.Visited == nil {
+			st.Visited = make(map[VisitedEntry]struct{})
+		}
+		st.Visited[e] = struct{}{}
+		defer delete(st.Visited, e)
+	}
+	if ss.maxDepth != nil {
+		st.Depth = len(st.Path)
+		maxReached, previousMax := ss.maxDepth.enterMaxDepth(st, v, itfType)
+		defer ss.maxDepth.postMaxDepth(st, previousMax)
+		if maxReached {
+			ss.addMaxDepthReached(v)
+			return
+		}
+	}
+	if ss.selector.MaxDepth > 0 && len(st.Path) >= ss.selector.MaxDepth {
+		ss.addMaxDepthReached(v)
+		return
+	}
+	ss.selector.rangeChildren(v, func(e PathElement, child reflect.Value) bool {
+		st.PushPath(e)
+		ss.visit(child)
+		st.PopPath()
+		return true
+	})
+}
+
+func (ss *statsState) addMaxDepthReached(v reflect.Value) {
+	if ss.selector.hasChildren(v) {
+		ss.maxDepthReached++
+	}
+}
+
+func (ss *statsState) addLargest(typ reflect.Type, l int) {
+	if l == 0 {
+		return
+	}
+	if len(ss.largest) == statsLargestCount && l <= ss.largest[len(ss.largest)-1].len {
+		return
+	}
+	i, _ := slices.BinarySearchFunc(ss.largest, l, func(e statsLargest, l int) int {
+		return cmp.Compare(l, e.len) // Descending.
+	})
+	for i < len(ss.largest) && ss.largest[i].len == l {
+		i++
+	}
+	ss.largest = slices.Insert(ss.largest, i, statsLargest{
+		path: cloneSelectPath(ss.st.Path),
+		typ:  typ,
+		len:  l,
+	})
+	if len(ss.largest) > statsLargestCount {
+		ss.largest = ss.largest[:statsLargestCount]
+	}
+}
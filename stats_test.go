@@ -0,0 +1,108 @@
+package pretty_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pierrre/assert"
+	. "github.com/pierrre/pretty"
+)
+
+func TestStringStats(t *testing.T) {
+	s := StringStats(newTestSearchValue())
+	assert.StringContains(t, s, "Nodes: [int] 17,")
+}
+
+func TestStats(t *testing.T) {
+	stats := Stats(newTestSearchValue())
+	assert.Equal(t, stats.Nodes, 17)
+	assert.Equal(t, stats.MaxDepth, 2)
+	assert.Equal(t, stats.Nils, 1)
+	assert.Equal(t, stats.Errors, 1)
+	assert.SliceEqual(t, stats.Types, []ValueStatsType{
+		{Type: "string", Count: 10},
+		{Type: "*errors.errorString", Count: 1},
+		{Type: "*github.com/pierrre/pretty_test.testSearchValue", Count: 1},
+		{Type: "[]string", Count: 1},
+		{Type: "func(func(string) bool)", Count: 1},
+		{Type: "github.com/pierrre/pretty_test.testSearchValue", Count: 1},
+		{Type: "int", Count: 1},
+		{Type: "map[string]string", Count: 1},
+	})
+	assert.SliceEqual(t, stats.Largest, []ValueStatsLargest{
+		{Path: "$.unexported", Type: "string", Len: 6},
+		{Path: "$.Err.s", Type: "string", Len: 5},
+		{Path: "$.Name", Type: "string", Len: 3},
+		{Path: "$.Tags", Type: "[]string", Len: 3},
+		{Path: "$.Tags[0]", Type: "string", Len: 3},
+		{Path: "$.Tags[2]", Type: "string", Len: 3},
+		{Path: `$.Labels["a"]`, Type: "string", Len: 3},
+		{Path: `$.Labels["b"]`, Type: "string", Len: 3},
+		{Path: "$.Seq[0]", Type: "string", Len: 3},
+		{Path: "$.Seq[1]", Type: "string", Len: 3},
+	})
+}
+
+func TestStatsRecursion(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	n := &node{Name: "test"}
+	n.Next = n
+	stats := Stats(n)
+	assert.Equal(t, stats.Recursions, 1)
+}
+
+func TestStatsMaxDepth(t *testing.T) {
+	s := NewSelector()
+	s.MaxDepth = 1
+	stats := s.Stats(newTestSearchValue())
+	assert.Equal(t, stats.MaxDepth, 1)
+	assert.Equal(t, stats.MaxDepthReached, 4)
+}
+
+func TestStatsNil(t *testing.T) {
+	stats := Stats(nil)
+	assert.Equal(t, stats.Nodes, 1)
+	assert.Equal(t, stats.Nils, 1)
+}
+
+func TestStatsRecursionMap(t *testing.T) {
+	m := map[string]any{}
+	m["self"] = m
+	m["slice"] = []any{m}
+	stats := Stats(m)
+	assert.Equal(t, stats.Recursions, 2)
+}
+
+func TestPrinterStatsMaxByPath(t *testing.T) {
+	vw := NewCommonWriter()
+	vw.MaxDepth.MaxByPath = map[string]int{
+		".Tags": 0,
+	}
+	p := NewPrinter(vw)
+	stats := p.Stats(newTestSearchValue())
+	assert.Equal(t, stats.Nodes, 14)
+	assert.Equal(t, stats.MaxDepthReached, 1)
+}
+
+func TestPrinterStatsMaxByTypeInterface(t *testing.T) {
+	vw := NewCommonWriter()
+	vw.MaxDepth.MaxByType = map[reflect.Type]int{
+		reflect.TypeFor[error](): 0,
+	}
+	p := NewPrinter(vw)
+	stats := p.Stats(newTestSearchValue())
+	assert.Equal(t, stats.Nodes, 16)
+	assert.Equal(t, stats.MaxDepthReached, 1)
+}
+
+func TestPrinterStatsMax(t *testing.T) {
+	vw := NewCommonWriter()
+	vw.MaxDepth.Max = 1
+	p := NewPrinter(vw)
+	stats := p.Stats(newTestSearchValue())
+	assert.Equal(t, stats.MaxDepth, 1)
+	assert.Equal(t, stats.MaxDepthReached, 4)
+}
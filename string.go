@@ -3,6 +3,8 @@ package pretty
 import (
 	"reflect"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
 // StringWriter is a [ValueWriter] that handles string values.
@@ -19,18 +21,33 @@ type StringWriter struct {
 	// Default: true.
 	Quote bool
 	// MaxLen is the maximum length of the string, in bytes.
-	// If the string exceeds this length, it is truncated.
+	// If the string exceeds this length, it is truncated (see Truncate).
 	// Default: 0 (no limit).
 	MaxLen int
+	// Truncate is the mode used to truncate the string.
+	// Default: [TruncateModeHead].
+	Truncate TruncateMode
+	// Block writes the strings containing new lines as an indented text block, delimited by `"""`.
+	// Only the non-printable characters, the backslashes and the `"""` sequences are escaped.
+	// The new line before the closing delimiter is not part of the string, so a trailing new line is written as an empty line.
+	// It is ignored in compact mode.
+	// Default: false.
+	Block bool
+	// BlockMinLen is the minimum length of the string, in bytes, for Block.
+	// Default: 0.
+	BlockMinLen int
 }
 
 // NewStringWriter creates a new [StringWriter] with default values.
 func NewStringWriter() *StringWriter {
 	return &StringWriter{
-		ShowLen:  true,
-		ShowAddr: false,
-		Quote:    true,
-		MaxLen:   0,
+		ShowLen:     true,
+		ShowAddr:    false,
+		Quote:       true,
+		MaxLen:      0,
+		Truncate:    TruncateModeHead,
+		Block:       false,
+		BlockMinLen: 0,
 	}
 }
 
@@ -39,7 +56,9 @@ func (vw *StringWriter) WriteValue(st *State, v reflect.Value) bool {
 	if v.Kind() != reflect.String {
 		return false
 	}
-	writeStringValue(st, v.String(), vw.ShowLen, vw.ShowAddr, uintptr(v.UnsafePointer()), vw.Quote, vw.MaxLen)
+	s := v.String()
+	block := vw.Block && isStringBlock(st, s, vw.BlockMinLen)
+	writeStringValue(st, s, vw.ShowLen, vw.ShowAddr, uintptr(v.UnsafePointer()), vw.Quote, vw.MaxLen, vw.Truncate, block)
 	return true
 }
 
@@ -52,25 +71,106 @@ func (vw *StringWriter) Supports(typ reflect.Type) ValueWriter {
 	return res
 }
 
-func writeStringValue(st *State, s string, showLen bool, showAddr bool, addr uintptr, quote bool, maxLen int) {
+func writeStringValue(st *State, s string, showLen bool, showAddr bool, addr uintptr, quote bool, maxLen int, truncate TruncateMode, block bool) {
 	infos{
 		showLen:  showLen,
 		len:      len(s),
 		showAddr: showAddr,
 		addr:     addr,
 	}.writeWithTrailingSpace(st)
-	truncated := false
-	if maxLen > 0 && len(s) > maxLen {
-		s = s[:maxLen]
-		truncated = true
+	head, tail, omitted := getTruncateLens(len(s), maxLen, truncate)
+	tailStr := s[len(s)-tail:]
+	s = s[:head]
+	if block {
+		writeStringBlock(st, s, omitted, tailStr)
+		return
+	}
+	writeStringPart(st, s, quote)
+	if omitted > 0 {
+		st.Writer.AppendByte(' ')
+		writeOmitted(st, omitted)
+		if tail > 0 {
+			st.Writer.AppendByte(' ')
+			writeStringPart(st, tailStr, quote)
+		}
 	}
+}
+
+func writeStringPart(st *State, s string, quote bool) {
 	if quote {
 		st.Writer = strconv.AppendQuote(st.Writer, s)
 	} else {
 		st.Writer.AppendString(s)
 	}
-	if truncated {
+}
+
+// isStringBlock returns true if the string must be written as a text block.
+func isStringBlock(st *State, s string, minLen int) bool {
+	return !st.Compact && len(s) >= minLen && strings.IndexByte(s, '\n') >= 0
+}
+
+const (
+	stringBlockDelimiter = `"""`
+	hexDigits            = "0123456789abcdef"
+)
+
+// writeStringBlock writes a string as an indented text block.
+//
+// If omitted is greater than 0, the omitted marker is written after the string, followed by the tail in the same block.
+// If the tail is empty, the marker is written after the block.
+func writeStringBlock(st *State, s string, omitted int, tail string) {
+	st.Writer.AppendString(stringBlockDelimiter)
+	st.writeNewLine()
+	st.IndentLevel++
+	bw := bytesWriterPool.Get()
+	*bw = appendStringBlock(*bw, s)
+	if omitted > 0 && tail != "" {
+		*bw = appendOmitted(*bw, omitted)
+		*bw = appendStringBlock(*bw, tail)
+		s = tail
+	}
+	trailingNewLine := strings.HasSuffix(s, "\n")
+	if trailingNewLine {
+		*bw = append(*bw, '\n') // Writes an empty line, so the trailing new line is visible.
+	}
+	iw := st.newIndentWriter()
+	_, _ = iw.Write(*bw)
+	iw.Release()
+	bytesWriterPool.Put(bw)
+	st.IndentLevel--
+	if !trailingNewLine {
+		st.writeNewLine()
+	}
+	st.WriteIndent()
+	st.Writer.AppendString(stringBlockDelimiter)
+	if omitted > 0 && tail == "" {
 		st.Writer.AppendByte(' ')
-		writeTruncated(st)
+		writeOmitted(st, omitted)
+	}
+}
+
+// appendStringBlock appends the string, and escapes the non-printable characters (except new lines and tabs).
+//
+// It also escapes the backslashes, and the first quote of the `"""` sequences, so the content can't be confused with the escape sequences and the delimiter.
+func appendStringBlock(dst []byte, s string) []byte {
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		switch {
+		case r == '\\':
+			dst = append(dst, `\\`...)
+		case r == '"' && strings.HasPrefix(s, stringBlockDelimiter):
+			dst = append(dst, `\"`...)
+		case r == utf8.RuneError && size == 1:
+			dst = append(dst, `\x`...)
+			dst = append(dst, hexDigits[s[0]>>4], hexDigits[s[0]&0xf])
+		case r == '\n' || r == '\t' || strconv.IsPrint(r):
+			dst = append(dst, s[:size]...)
+		default:
+			l := len(dst)
+			dst = strconv.AppendQuoteRuneToASCII(dst, r)
+			dst = append(dst[:l], dst[l+1:len(dst)-1]...) // Removes the quotes.
+		}
+		s = s[size:]
 	}
+	return dst
 }
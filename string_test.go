@@ -32,6 +32,76 @@ func init() {
 				vw.Kind.String.Quote = false
 			},
 		},
+		{
+			Name: "Block",
+			Value: map[string]string{
+				"query":   "SELECT *\nFROM t\n\tWHERE x = 1",
+				"newline": "a\nb\n",
+				"escape":  "a\x00\r\nb\xff\u00e9",
+				"oneline": "test",
+			},
+			ConfigureWriter: configureTestStringBlock,
+		},
+		{
+			Name: "BlockEscape",
+			Value: []string{
+				"a\nb",
+				"a\nb\n",
+				"a\n\n",
+				"a\n\"\"\"b\"\"\"\"",
+				"a\nb\\",
+				"a\n\\x00",
+			},
+			ConfigureWriter: configureTestStringBlock,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "BlockMinLen",
+			Value: []string{"a\nb", "aaaa\nbbbb"},
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestStringBlock(vw)
+				vw.Kind.String.BlockMinLen = 5
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "BlockTruncated",
+			Value: "aaa\nbbb\nccc",
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestStringBlock(vw)
+				vw.Kind.String.MaxLen = 6
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "BlockTruncateMiddle",
+			Value: "aaa\nbbb\nccc\nddd",
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestStringBlock(vw)
+				vw.Kind.String.MaxLen = 8
+				vw.Kind.String.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "BlockCompact",
+			Value: "aaa\nbbb",
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: configureTestStringBlock,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "BlockGutter",
+			Value: []string{"aaa\nbbb"},
+			ConfigurePrinter: func(p *Printer) {
+				p.PathAnnotation = PathAnnotationGutter
+				p.PathGutterWidth = 8
+			},
+			ConfigureWriter: configureTestStringBlock,
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:  "Truncated",
 			Value: "test",
@@ -40,6 +110,25 @@ func init() {
 			},
 			IgnoreBenchmark: true,
 		},
+		{
+			Name:  "TruncateMiddle",
+			Value: "abcdefgh",
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.String.MaxLen = 5
+				vw.Kind.String.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "TruncateMiddleUnquoted",
+			Value: "abcdefgh",
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.String.Quote = false
+				vw.Kind.String.MaxLen = 4
+				vw.Kind.String.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:  "SupportDisabled",
 			Value: "test",
@@ -57,3 +146,7 @@ func init() {
 		},
 	})
 }
+
+func configureTestStringBlock(vw *CommonWriter) {
+	vw.Kind.String.Block = true
+}
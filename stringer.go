@@ -23,17 +23,29 @@ type StringerWriter struct {
 	// Default: true.
 	Quote bool
 	// MaxLen is the maximum length of the string, in bytes.
-	// If the string exceeds this length, it is truncated.
+	// If the string exceeds this length, it is truncated (see Truncate).
 	// Default: 0 (no limit).
 	MaxLen int
+	// Truncate is the mode used to truncate the string.
+	// Default: [TruncateModeHead].
+	Truncate TruncateMode
+	// Block writes the strings containing new lines as an indented text block (see [StringWriter.Block]).
+	// Default: false.
+	Block bool
+	// BlockMinLen is the minimum length of the string, in bytes, for Block.
+	// Default: 0.
+	BlockMinLen int
 }
 
 // NewStringerWriter creates a new [StringerWriter].
 func NewStringerWriter() *StringerWriter {
 	return &StringerWriter{
-		ShowLen: false,
-		Quote:   true,
-		MaxLen:  0,
+		ShowLen:     false,
+		Quote:       true,
+		MaxLen:      0,
+		Truncate:    TruncateModeHead,
+		Block:       false,
+		BlockMinLen: 0,
 	}
 }
 
@@ -62,7 +74,8 @@ func (vw *StringerWriter) WriteValue(st *State, v reflect.Value) bool {
 		return false
 	}
 	writeArrowWrappedString(st, "String() ")
-	writeStringValue(st, s, vw.ShowLen, false, 0, vw.Quote, vw.MaxLen)
+	block := vw.Block && isStringBlock(st, s, vw.BlockMinLen)
+	writeStringValue(st, s, vw.ShowLen, false, 0, vw.Quote, vw.MaxLen, vw.Truncate, block)
 	return true
 }
 
@@ -18,6 +18,14 @@ func init() {
 			Value:           (*testStringer)(nil),
 			IgnoreBenchmark: true,
 		},
+		{
+			Name:  "Block",
+			Value: &testStringer{s: "aaa\nbbb"},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Stringer.ValueWriter.Block = true
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:  "Truncated",
 			Value: &testStringer{s: "test"},
@@ -26,6 +34,15 @@ func init() {
 			},
 			IgnoreBenchmark: true,
 		},
+		{
+			Name:  "TruncateMiddle",
+			Value: &testStringer{s: "abcdefgh"},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Stringer.ValueWriter.MaxLen = 4
+				vw.Stringer.ValueWriter.Truncate = TruncateModeMiddle
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name:  "ReflectValue",
 			Value: reflect.ValueOf(123),
@@ -1,9 +1,13 @@
 package pretty
 
 import (
+	"cmp"
 	"reflect"
+	"slices"
+	"strconv"
 
 	"github.com/pierrre/go-libs/reflectutil"
+	"github.com/pierrre/go-libs/syncutil"
 )
 
 // StructWriter is a [ValueWriter] that handles struct values.
@@ -17,14 +21,29 @@ type StructWriter struct {
 	// ShowFieldsType shows the type of the fields.
 	// Default: true.
 	ShowFieldsType bool
+	// AlignFields pads the field names, so all the values start at the same column.
+	// Default: false.
+	AlignFields bool
+	// ShowLayout shows the memory layout.
+	// For each field, it shows the offset, size, alignment and padding (inserted before the field), e.g. "(offset=8 size=8 align=8 padding=7)".
+	// For the struct, it shows the size, alignment and total padding.
+	// Default: false.
+	ShowLayout bool
+	// ShowOptimalLayout shows the field order that minimizes the padding, if it reduces the size, e.g. "(... optimal_size=16 optimal_order=B,A)".
+	// It requires ShowLayout.
+	// Default: false.
+	ShowOptimalLayout bool
 }
 
 // NewStructWriter creates a new [StructWriter] with default values.
 func NewStructWriter(vw ValueWriter) *StructWriter {
 	return &StructWriter{
-		ValueWriter:    vw,
-		FieldFilter:    nil,
-		ShowFieldsType: true,
+		ValueWriter:       vw,
+		FieldFilter:       nil,
+		ShowFieldsType:    true,
+		AlignFields:       false,
+		ShowLayout:        false,
+		ShowOptimalLayout: false,
 	}
 }
 
@@ -33,34 +52,150 @@ func (vw *StructWriter) WriteValue(st *State, v reflect.Value) bool {
 	if v.Kind() != reflect.Struct {
 		return false
 	}
+	if vw.ShowLayout {
+		vw.writeLayout(st, v.Type())
+	}
 	st.Writer.AppendByte('{')
 	fields := reflectutil.GetStructFields(v.Type())
+	var fieldsEnd uintptr
 	hasFields := false
+	align := 0
+	if vw.AlignFields {
+		align = st.AlignBlockStart()
+	}
 	st.IndentLevel++
 	fields.Range(func(i int, field reflect.StructField) bool {
+		padding := field.Offset - fieldsEnd
+		fieldsEnd = field.Offset + field.Type.Size()
 		if vw.FieldFilter != nil && !vw.FieldFilter(v, field) {
 			return true
 		}
-		if !hasFields {
-			st.Writer.AppendByte('\n')
-			hasFields = true
+		st.PushPath(PathElement{Kind: PathElementField, Name: field.Name})
+		st.WriteBlockItemStart(!hasFields)
+		hasFields = true
+		keyStart := len(st.Writer)
+		if vw.ShowLayout {
+			writeStructFieldLayout(st, field, padding)
 		}
-		st.WriteIndent()
 		st.Writer.AppendString(field.Name)
 		st.Writer.AppendString(": ")
+		if vw.AlignFields {
+			st.AlignBlockItem(keyStart)
+		}
 		st.KnownType = !vw.ShowFieldsType
+		structField := st.structField
+		st.structField = structFieldHint{
+			typ:     v.Type(),
+			index:   i,
+			pathLen: len(st.Path),
+		}
 		vw.ValueWriter.WriteValue(st, v.Field(i))
-		st.Writer.AppendString(",\n")
+		st.structField = structField
+		st.WriteBlockItemEnd()
+		st.PopPath()
 		return true
 	})
 	st.IndentLevel--
-	if hasFields {
-		st.WriteIndent()
+	if vw.AlignFields {
+		st.AlignBlockEnd(align)
 	}
+	st.WriteBlockEnd(hasFields)
 	st.Writer.AppendByte('}')
 	return true
 }
 
+func (vw *StructWriter) writeLayout(st *State, typ reflect.Type) {
+	size := typ.Size()
+	st.Writer.AppendString("(size=")
+	st.Writer = strconv.AppendUint(st.Writer, uint64(size), 10)
+	st.Writer.AppendString(" align=")
+	st.Writer = strconv.AppendInt(st.Writer, int64(typ.Align()), 10)
+	padding := size
+	for i := range typ.NumField() {
+		padding -= typ.Field(i).Type.Size()
+	}
+	st.Writer.AppendString(" padding=")
+	st.Writer = strconv.AppendUint(st.Writer, uint64(padding), 10)
+	if vw.ShowOptimalLayout && padding > 0 {
+		vw.writeOptimalLayout(st, typ)
+	}
+	st.Writer.AppendString(") ")
+}
+
+// structOptimalLayouts contains the optimal layouts by struct type (see [getStructOptimalLayout]).
+var structOptimalLayouts syncutil.Map[reflect.Type, string]
+
+func (vw *StructWriter) writeOptimalLayout(st *State, typ reflect.Type) {
+	s, ok := structOptimalLayouts.Load(typ)
+	if !ok {
+		s = getStructOptimalLayout(typ)
+		structOptimalLayouts.Store(typ, s)
+	}
+	st.Writer.AppendString(s)
+}
+
+// getStructOptimalLayout returns the optimal layout of a struct type, e.g. " optimal_size=16 optimal_order=B,A".
+// It returns an empty string if it doesn't reduce the size.
+func getStructOptimalLayout(typ reflect.Type) string {
+	fields := make([]reflect.StructField, typ.NumField())
+	for i := range fields {
+		fields[i] = typ.Field(i)
+	}
+	// Zero-size fields first (a trailing zero-size field requires padding), then by decreasing alignment.
+	slices.SortStableFunc(fields, func(a, b reflect.StructField) int { //nolint:gocritic // The StructField type is large, but we need to use it.
+		return cmp.Or(
+			cmp.Compare(min(a.Type.Size(), 1), min(b.Type.Size(), 1)),
+			cmp.Compare(b.Type.Align(), a.Type.Align()),
+		)
+	})
+	size := getStructLayoutSize(fields, typ.Align())
+	if size >= typ.Size() {
+		return ""
+	}
+	var b []byte
+	b = append(b, " optimal_size="...)
+	b = strconv.AppendUint(b, uint64(size), 10)
+	b = append(b, " optimal_order="...)
+	for i, field := range fields {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, field.Name...)
+	}
+	return string(b)
+}
+
+// getStructLayoutSize returns the size of a struct with the fields in this order.
+func getStructLayoutSize(fields []reflect.StructField, align int) uintptr {
+	var offset uintptr
+	for _, field := range fields {
+		offset = alignUp(offset, uintptr(field.Type.Align()))
+		offset += field.Type.Size()
+	}
+	if len(fields) > 0 && fields[len(fields)-1].Type.Size() == 0 && offset > 0 {
+		offset++ // A trailing zero-size field must not point past the struct.
+	}
+	return alignUp(offset, uintptr(align))
+}
+
+func alignUp(n, align uintptr) uintptr {
+	return (n + align - 1) &^ (align - 1)
+}
+
+func writeStructFieldLayout(st *State, field reflect.StructField, padding uintptr) { //nolint:gocritic // The StructField type is large, but we need to use it.
+	st.Writer.AppendString("(offset=")
+	st.Writer = strconv.AppendUint(st.Writer, uint64(field.Offset), 10)
+	st.Writer.AppendString(" size=")
+	st.Writer = strconv.AppendUint(st.Writer, uint64(field.Type.Size()), 10)
+	st.Writer.AppendString(" align=")
+	st.Writer = strconv.AppendInt(st.Writer, int64(field.Type.FieldAlign()), 10)
+	if padding > 0 {
+		st.Writer.AppendString(" padding=")
+		st.Writer = strconv.AppendUint(st.Writer, uint64(padding), 10)
+	}
+	st.Writer.AppendString(") ")
+}
+
 // Supports implements [SupportChecker].
 func (vw *StructWriter) Supports(typ reflect.Type) ValueWriter {
 	var res ValueWriter
@@ -42,6 +42,89 @@ func init() {
 				vw.Kind.Struct.ShowFieldsType = false
 			},
 		},
+		{
+			Name:  "Layout",
+			Value: testLayoutStruct{},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Struct.ShowLayout = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "OptimalLayout",
+			Value: testLayoutStruct{},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Struct.ShowLayout = true
+				vw.Kind.Struct.ShowOptimalLayout = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "OptimalLayoutAlready",
+			Value: struct {
+				A int64
+				B bool
+			}{},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Struct.ShowLayout = true
+				vw.Kind.Struct.ShowOptimalLayout = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "OptimalLayoutZeroSize",
+			Value: struct {
+				A int32
+				B struct{}
+			}{},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Struct.ShowLayout = true
+				vw.Kind.Struct.ShowOptimalLayout = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "AlignFields",
+			Value: testAlignStruct{
+				ID:          1,
+				Description: "test",
+				Nested: testAlignStruct{
+					ID:          2,
+					Description: "nested",
+				},
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Struct.AlignFields = true
+			},
+		},
+		{
+			Name: "AlignFieldsGutter",
+			Value: testAlignStruct{
+				ID:          1,
+				Description: "test",
+			},
+			ConfigurePrinter: func(p *Printer) {
+				p.PathAnnotation = PathAnnotationGutter
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Struct.AlignFields = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "AlignFieldsCompact",
+			Value: testAlignStruct{
+				ID:          1,
+				Description: "test",
+			},
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Struct.AlignFields = true
+			},
+			IgnoreBenchmark: true,
+		},
 		{
 			Name: "SupportDisabled",
 			Value: testStruct{
@@ -69,3 +152,16 @@ type testStruct struct {
 	Bar        float64
 	unexported int
 }
+
+type testLayoutStruct struct {
+	A bool
+	B int64
+	C bool
+	D int32
+}
+
+type testAlignStruct struct {
+	ID          int
+	Description string
+	Nested      any
+}
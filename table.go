@@ -0,0 +1,352 @@
+package pretty
+
+import (
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/pierrre/go-libs/bytesutil"
+	"github.com/pierrre/go-libs/reflectutil"
+	"github.com/pierrre/go-libs/syncutil"
+)
+
+// TableWriter is a [ValueWriter] that writes slices, arrays and maps of structs as an aligned table.
+//
+// The columns are the exported fields of the struct, and the field names are used as headers.
+// The cells are written on a single line by the [ValueWriter].
+// Slice, array, map and chan cells are summarized with their length, and struct cells with "{...}".
+//
+// It is not enabled by default in [CommonWriter], see [CommonWriter.Table].
+//
+// It should be created with [NewTableWriter].
+type TableWriter struct {
+	ValueWriter
+	// ShowLen shows the len.
+	// Default: true.
+	ShowLen bool
+	// ShowIndexes shows the indexes of slices and arrays in the first column.
+	// The keys of maps are always shown.
+	// Default: false.
+	ShowIndexes bool
+	// MaxLen is the maximum number of rows.
+	// Default: 0 (no limit).
+	MaxLen int
+}
+
+// NewTableWriter creates a new [TableWriter] with default values.
+func NewTableWriter(vw ValueWriter) *TableWriter {
+	return &TableWriter{
+		ValueWriter: vw,
+		ShowLen:     true,
+		ShowIndexes: false,
+		MaxLen:      0,
+	}
+}
+
+// WriteValue implements [ValueWriter].
+//
+// It doesn't handle values in compact mode.
+func (vw *TableWriter) WriteValue(st *State, v reflect.Value) bool {
+	if st.Compact {
+		return false
+	}
+	typ := v.Type()
+	structTyp, ok := getTableStructType(typ)
+	if !ok {
+		return false
+	}
+	kind := v.Kind()
+	if kind != reflect.Array && checkNil(st, v) {
+		return true
+	}
+	l := v.Len()
+	infos{
+		showLen: vw.ShowLen,
+		len:     l,
+	}.writeWithTrailingSpace(st)
+	t := tablePool.Get()
+	defer t.release()
+	fields := reflectutil.GetStructFields(structTyp)
+	showKeys := kind == reflect.Map || vw.ShowIndexes
+	t.showKeys = showKeys
+	if showKeys {
+		t.addString("")
+	}
+	fields.Range(func(_ int, field reflect.StructField) bool {
+		if field.IsExported() {
+			t.addString(field.Name)
+		}
+		return true
+	})
+	t.columns = len(t.ends)
+	rows := l
+	if vw.MaxLen > 0 && rows > vw.MaxLen {
+		rows = vw.MaxLen
+	}
+	if kind == reflect.Map {
+		es := reflectutil.GetSortedMap(v)
+		defer es.Release()
+		for i := range rows {
+			e := es[i]
+			vw.addRow(st, t, fields, e.Key, e.Value)
+			t.paths = append(t.paths, PathElement{Kind: PathElementKey, Key: e.Key})
+		}
+		writeTable(st, t, l-rows)
+	} else {
+		for i := range rows {
+			vw.addRow(st, t, fields, reflect.ValueOf(i), v.Index(i))
+			t.paths = append(t.paths, PathElement{Kind: PathElementIndex, Index: i})
+		}
+		writeTable(st, t, l-rows)
+	}
+	return true
+}
+
+func (vw *TableWriter) addRow(st *State, t *table, fields reflectutil.StructFields, key reflect.Value, row reflect.Value) {
+	showInfos := st.ShowInfos
+	compact := st.Compact
+	st.ShowInfos = false
+	st.Compact = true
+	defer func() {
+		st.ShowInfos = showInfos
+		st.Compact = compact
+	}()
+	if t.showKeys {
+		t.addCell(st, func() {
+			vw.writeCell(st, key)
+		})
+	}
+	if row.Kind() == reflect.Pointer {
+		if row.IsNil() {
+			first := true
+			fields.Range(func(_ int, field reflect.StructField) bool {
+				if field.IsExported() {
+					t.addCell(st, func() {
+						if first {
+							writeNil(st)
+						}
+					})
+					first = false
+				}
+				return true
+			})
+			return
+		}
+		row = row.Elem()
+	}
+	fields.Range(func(i int, field reflect.StructField) bool {
+		if field.IsExported() {
+			t.addCell(st, func() {
+				vw.writeCell(st, row.Field(i))
+			})
+		}
+		return true
+	})
+}
+
+func (vw *TableWriter) writeCell(st *State, v reflect.Value) {
+	if v.Kind() == reflect.Interface {
+		if checkNil(st, v) {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() { //nolint:exhaustive // Other kinds are written by the ValueWriter.
+	case reflect.Slice, reflect.Map, reflect.Chan:
+		if checkNil(st, v) {
+			return
+		}
+		fallthrough
+	case reflect.Array:
+		// The infos are hidden in cells, so the summary is written directly.
+		st.Writer.AppendString("(len=")
+		st.Writer = strconv.AppendInt(st.Writer, int64(v.Len()), 10)
+		st.Writer.AppendByte(')')
+		return
+	case reflect.Pointer:
+		if !v.IsNil() && isTableRowType(v.Type().Elem()) {
+			st.Writer.AppendString("{...}")
+			return
+		}
+	case reflect.Struct:
+		if isTableRowType(v.Type()) {
+			st.Writer.AppendString("{...}")
+			return
+		}
+	}
+	st.KnownType = true
+	vw.ValueWriter.WriteValue(st, v)
+}
+
+// Supports implements [SupportChecker].
+func (vw *TableWriter) Supports(typ reflect.Type) ValueWriter {
+	var res ValueWriter
+	if _, ok := getTableStructType(typ); ok {
+		res = vw
+	}
+	return res
+}
+
+func getTableStructType(typ reflect.Type) (reflect.Type, bool) {
+	switch typ.Kind() { //nolint:exhaustive // Only handles slices, arrays and maps.
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return nil, false
+	}
+	elemTyp := typ.Elem()
+	if elemTyp.Kind() == reflect.Pointer {
+		elemTyp = elemTyp.Elem()
+	}
+	return elemTyp, isTableRowType(elemTyp)
+}
+
+// isTableRowType returns true if the type is a struct with exported fields.
+//
+// Structs without exported fields (e.g. [time.Time]) are considered as scalar values.
+func isTableRowType(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+	fields := reflectutil.GetStructFields(typ)
+	for i := range fields.Len() {
+		if fields.Get(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// table contains the cells of a table.
+//
+// The first row is the header.
+// Each other row has a [PathElement] in paths, except the elided row.
+type table struct {
+	cells      bytesutil.Writer
+	ends       []int
+	widths     []int
+	paths      []PathElement
+	columns    int
+	showKeys   bool
+	alignRight bool
+	hideHeader bool
+	elidedRow  int
+}
+
+var tablePool = syncutil.Pool[*table]{
+	New: func() *table {
+		return &table{
+			elidedRow: -1,
+		}
+	},
+}
+
+// writeTable writes the table, followed by the number of omitted rows, if it is not 0.
+func writeTable(st *State, t *table, omitted int) {
+	t.computeWidths()
+	st.Writer.AppendByte('{')
+	st.writeNewLine()
+	st.IndentLevel++
+	rows := len(t.ends) / t.columns
+	p := 0
+	for r := range rows {
+		if r == 0 && t.hideHeader {
+			continue
+		}
+		hasPath := r > 0 && r != t.elidedRow
+		if hasPath {
+			st.PushPath(t.paths[p])
+			p++
+		}
+		st.WriteIndent()
+		t.writeRow(st, r)
+		st.writeNewLine()
+		if hasPath {
+			st.PopPath()
+		}
+	}
+	if omitted > 0 {
+		writeBlockOmitted(st, false, omitted)
+	}
+	st.IndentLevel--
+	st.WriteIndent()
+	st.Writer.AppendByte('}')
+}
+
+func (t *table) addString(s string) {
+	t.cells.AppendString(s)
+	t.ends = append(t.ends, len(t.cells))
+}
+
+// addCell adds a cell written by the function to the [State] writer.
+func (t *table) addCell(st *State, f func()) {
+	start := len(st.Writer)
+	f()
+	t.cells = append(t.cells, st.Writer[start:]...)
+	st.Writer = st.Writer[:start]
+	t.ends = append(t.ends, len(t.cells))
+}
+
+func (t *table) cell(i int) []byte {
+	start := 0
+	if i > 0 {
+		start = t.ends[i-1]
+	}
+	return t.cells[start:t.ends[i]]
+}
+
+func (t *table) computeWidths() {
+	t.widths = t.widths[:0]
+	for range t.columns {
+		t.widths = append(t.widths, 0)
+	}
+	start := 0
+	if t.hideHeader {
+		start = t.columns
+	}
+	for i := start; i < len(t.ends); i++ {
+		c := i % t.columns
+		t.widths[c] = max(t.widths[c], utf8.RuneCount(t.cell(i)))
+	}
+}
+
+func (t *table) writeRow(st *State, r int) {
+	start := r * t.columns
+	last := t.columns - 1
+	for last > 0 && len(t.cell(start+last)) == 0 {
+		last-- // Avoids trailing spaces.
+	}
+	for c := range last + 1 {
+		cell := t.cell(start + c)
+		padding := t.widths[c] - utf8.RuneCount(cell)
+		if t.alignRight {
+			if c > 0 {
+				padding += 2
+			}
+			writeSpaces(st, padding)
+			st.Writer.Append(cell)
+			continue
+		}
+		st.Writer.Append(cell)
+		if c < last {
+			writeSpaces(st, padding+2)
+		}
+	}
+}
+
+func (t *table) release() {
+	t.cells.Reset()
+	t.ends = t.ends[:0]
+	clear(t.paths)
+	t.paths = t.paths[:0]
+	t.showKeys = false
+	t.alignRight = false
+	t.hideHeader = false
+	t.elidedRow = -1
+	tablePool.Put(t)
+}
+
+func writeSpaces(st *State, n int) {
+	for range n {
+		st.Writer.AppendByte(' ')
+	}
+}
@@ -0,0 +1,167 @@
+package pretty_test
+
+import (
+	"time"
+
+	. "github.com/pierrre/pretty"
+	"github.com/pierrre/pretty/internal/prettytest"
+)
+
+func init() {
+	prettytest.AddCasesPrefix("Table", []*prettytest.Case{
+		{
+			Name:            "Default",
+			Value:           newTestTableRows(),
+			ConfigureWriter: configureTestTable,
+		},
+		{
+			Name:            "Nil",
+			Value:           []testTableRow(nil),
+			ConfigureWriter: configureTestTable,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Empty",
+			Value:           []testTableRow{},
+			ConfigureWriter: configureTestTable,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Array",
+			Value:           [2]testTableRow(newTestTableRows()),
+			ConfigureWriter: configureTestTable,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "Pointer",
+			Value: func() []*testTableRow {
+				rows := newTestTableRows()
+				return []*testTableRow{&rows[0], nil, &rows[1]}
+			}(),
+			ConfigureWriter: configureTestTable,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "Map",
+			Value: func() map[string]testTableRow {
+				rows := newTestTableRows()
+				return map[string]testTableRow{
+					"b": rows[1],
+					"a": rows[0],
+				}
+			}(),
+			ConfigureWriter: configureTestTable,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "ShowIndexes",
+			Value: newTestTableRows(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestTable(vw)
+				vw.Table.ShowIndexes = true
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Truncated",
+			Value: newTestTableRows(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestTable(vw)
+				vw.Table.MaxLen = 1
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "StringMaxLen",
+			Value: newTestTableRows(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestTable(vw)
+				vw.Kind.String.MaxLen = 3
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:            "Nested",
+			Value:           []testTableRow{{Nested: []testTableRow{{}, {}}}},
+			ConfigureWriter: configureTestTable,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Compact",
+			Value: newTestTableRows(),
+			ConfigurePrinter: func(p *Printer) {
+				p.Compact = true
+			},
+			ConfigureWriter: configureTestTable,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "PathComment",
+			Value: newTestTableRows(),
+			ConfigurePrinter: func(p *Printer) {
+				p.PathAnnotation = PathAnnotationComment
+			},
+			ConfigureWriter: configureTestTable,
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "SupportDisabled",
+			Value: newTestTableRows(),
+			ConfigureWriter: func(vw *CommonWriter) {
+				configureTestTable(vw)
+				vw.Support = nil
+			},
+		},
+		{
+			Name:  "Not",
+			Value: []int{1, 2, 3},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.ValueWriters = ValueWriters{NewTableWriter(vw)}
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "NotUnexportedFields",
+			Value: []time.Time{time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.ValueWriters = ValueWriters{NewTableWriter(vw)}
+			},
+			IgnoreBenchmark: true,
+		},
+	})
+}
+
+type testTableRow struct {
+	ID       int
+	Name     string
+	Enabled  bool
+	Created  time.Time
+	Tags     []string
+	Nested   []testTableRow
+	Value    any
+	internal int
+}
+
+func newTestTableRows() []testTableRow {
+	return []testTableRow{
+		{
+			ID:      1,
+			Name:    "first",
+			Enabled: true,
+			Created: time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC),
+			Tags:    []string{"a", "b", "c"},
+			Value:   1.5,
+		},
+		{
+			ID:       1234,
+			Name:     "second élément",
+			Created:  time.Date(2024, 12, 31, 23, 59, 59, 0, time.UTC),
+			Value:    testTableRow{ID: 1},
+			internal: 1,
+		},
+	}
+}
+
+func configureTestTable(vw *CommonWriter) {
+	vw.Table = NewTableWriter(vw)
+}
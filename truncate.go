@@ -0,0 +1,45 @@
+package pretty
+
+import (
+	"strconv"
+)
+
+// TruncateMode is the mode used to truncate the values that exceed their max length.
+type TruncateMode int
+
+const (
+	// TruncateModeHead keeps the head and omits the tail, e.g. `"abc" <... 3 more ...>`.
+	TruncateModeHead TruncateMode = iota
+	// TruncateModeMiddle keeps the head and the tail, and omits the middle, e.g. `"ab" <... 2 more ...> "ef"`.
+	TruncateModeMiddle
+)
+
+// getTruncateLens returns the lengths of the head and the tail to keep, and the number of omitted elements.
+func getTruncateLens(l int, maxLen int, mode TruncateMode) (head int, tail int, omitted int) {
+	if maxLen <= 0 || l <= maxLen {
+		return l, 0, 0
+	}
+	if mode == TruncateModeMiddle {
+		tail = maxLen / 2
+	}
+	head = maxLen - tail
+	return head, tail, l - maxLen
+}
+
+func writeOmitted(st *State, omitted int) {
+	st.Writer = appendOmitted(st.Writer, omitted)
+}
+
+func appendOmitted(dst []byte, omitted int) []byte {
+	dst = append(dst, "<... "...)
+	dst = strconv.AppendInt(dst, int64(omitted), 10)
+	return append(dst, " more ...>"...)
+}
+
+func writeBlockOmitted(st *State, first bool, omitted int) {
+	st.WriteBlockItemStart(first)
+	writeOmitted(st, omitted)
+	if !st.Compact {
+		st.writeNewLine()
+	}
+}
@@ -12,12 +12,21 @@ type UintWriter struct {
 	// Base is the base used to format the integer.
 	// Default: 10.
 	Base int
+	// NumberFormat is the format of the number.
+	// Default: zero value.
+	NumberFormat NumberFormat
+	// Flags writes the flags types registered with [RegisterFlags].
+	// The other types are not affected.
+	// Default: [NewFlagsWriter].
+	Flags *FlagsWriter
 }
 
 // NewUintWriter creates a new [UintWriter] with default values.
 func NewUintWriter() *UintWriter {
 	return &UintWriter{
-		Base: 10,
+		Base:         10,
+		NumberFormat: NumberFormat{},
+		Flags:        NewFlagsWriter(),
 	}
 }
 
@@ -28,7 +37,14 @@ func (vw *UintWriter) WriteValue(st *State, v reflect.Value) bool {
 	default:
 		return false
 	}
-	st.Writer = strconv.AppendUint(st.Writer, v.Uint(), vw.Base)
+	if vw.Flags != nil && vw.Flags.WriteValue(st, v) {
+		return true
+	}
+	if vw.NumberFormat == (NumberFormat{}) {
+		st.Writer = strconv.AppendUint(st.Writer, v.Uint(), vw.Base)
+		return true
+	}
+	st.Writer = vw.NumberFormat.appendInteger(st.Writer, false, v.Uint(), vw.Base, v.Type().Bits())
 	return true
 }
 
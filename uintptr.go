@@ -7,12 +7,21 @@ import (
 
 // UintptrWriter is a [ValueWriter] that handles uintptr values.
 //
+// It writes them in base 16, with the "0x" prefix.
+//
 // It should be created with [NewUintptrWriter].
-type UintptrWriter struct{}
+type UintptrWriter struct {
+	// NumberFormat is the format of the number.
+	// ShowBasePrefix is ignored, because the prefix is always shown.
+	// Default: zero value.
+	NumberFormat NumberFormat
+}
 
 // NewUintptrWriter creates a new [UintptrWriter].
 func NewUintptrWriter() *UintptrWriter {
-	return &UintptrWriter{}
+	return &UintptrWriter{
+		NumberFormat: NumberFormat{},
+	}
 }
 
 // WriteValue implements [ValueWriter].
@@ -20,7 +29,13 @@ func (vw *UintptrWriter) WriteValue(st *State, v reflect.Value) bool {
 	if v.Kind() != reflect.Uintptr {
 		return false
 	}
-	writeUintptr(st, uintptr(v.Uint()))
+	if vw.NumberFormat == (NumberFormat{}) {
+		writeUintptr(st, uintptr(v.Uint()))
+		return true
+	}
+	nf := vw.NumberFormat
+	nf.ShowBasePrefix = true
+	st.Writer = nf.appendInteger(st.Writer, false, v.Uint(), 16, v.Type().Bits())
 	return true
 }
 
@@ -37,3 +52,23 @@ func writeUintptr(st *State, p uintptr) {
 	st.Writer.AppendString("0x")
 	st.Writer = strconv.AppendUint(st.Writer, uint64(p), 16)
 }
+
+// writeAddr writes an address.
+//
+// With [State.SymbolicAddr], it writes a symbolic token instead of the real address.
+func writeAddr(st *State, p uintptr) {
+	if !st.SymbolicAddr || p == 0 {
+		writeUintptr(st, p)
+		return
+	}
+	id, ok := st.addrs[p]
+	if !ok {
+		if st.addrs == nil {
+			st.addrs = make(map[uintptr]int)
+		}
+		id = len(st.addrs) + 1
+		st.addrs[p] = id
+	}
+	st.Writer.AppendString("addr#")
+	st.Writer = strconv.AppendInt(st.Writer, int64(id), 10)
+}
@@ -0,0 +1,246 @@
+package pretty
+
+import (
+	"math"
+	"path"
+	"reflect"
+	"time"
+
+	"github.com/pierrre/go-libs/syncutil"
+)
+
+// Unit is the unit of an integer value, used by [UnitWriter].
+type Unit int
+
+const (
+	// UnitNone doesn't annotate the value.
+	UnitNone Unit = iota
+	// UnitBytes annotates a size in bytes, e.g. `1073741824 (1GiB)`.
+	// Its tag name is "bytes".
+	UnitBytes
+	// UnitNanoseconds annotates a duration in nanoseconds, e.g. `1500 (1.5µs)`.
+	// Its tag name is "ns".
+	UnitNanoseconds
+	// UnitMicroseconds annotates a duration in microseconds, e.g. `1500 (1.5ms)`.
+	// Its tag name is "us".
+	UnitMicroseconds
+	// UnitMilliseconds annotates a duration in milliseconds, e.g. `1500 (1.5s)`.
+	// Its tag name is "ms".
+	UnitMilliseconds
+	// UnitSeconds annotates a duration in seconds, e.g. `90 (1m30s)`.
+	// Its tag name is "s".
+	UnitSeconds
+	// UnitUnixSeconds annotates a unix timestamp in seconds, e.g. `1700000000 (2023-11-14T22:13:20Z)`.
+	// Its tag name is "unix".
+	UnitUnixSeconds
+	// UnitUnixMilliseconds annotates a unix timestamp in milliseconds.
+	// Its tag name is "unix_ms".
+	UnitUnixMilliseconds
+	// UnitUnixMicroseconds annotates a unix timestamp in microseconds.
+	// Its tag name is "unix_us".
+	UnitUnixMicroseconds
+	// UnitUnixNanoseconds annotates a unix timestamp in nanoseconds.
+	// Its tag name is "unix_ns".
+	UnitUnixNanoseconds
+)
+
+var unitsByTagName = map[string]Unit{
+	"bytes":   UnitBytes,
+	"ns":      UnitNanoseconds,
+	"us":      UnitMicroseconds,
+	"ms":      UnitMilliseconds,
+	"s":       UnitSeconds,
+	"unix":    UnitUnixSeconds,
+	"unix_ms": UnitUnixMilliseconds,
+	"unix_us": UnitUnixMicroseconds,
+	"unix_ns": UnitUnixNanoseconds,
+}
+
+// UnitFieldName associates a struct field name pattern to a [Unit].
+type UnitFieldName struct {
+	// Pattern is matched against the field name with [path.Match], e.g. "*Bytes".
+	Pattern string
+	Unit    Unit
+}
+
+// UnitWriter is a [ValueWriter] that annotates integer values with a human readable value, depending on their [Unit], e.g. `1500 (1.5s)`.
+//
+// The unit is selected with, in order of priority:
+//   - the struct tag of the field, e.g. `unit:"ms"` (see the tag names of [Unit])
+//   - the name of the field (see [UnitWriter.FieldNames])
+//   - the type (see [UnitWriter.Types])
+//
+// The number is written by the wrapped [ValueWriter], and the human readable value is added after it.
+// If the value overflows the unit (e.g. a duration larger than [time.Duration]), only the number is written.
+// The tags with an unknown name are ignored, because the same tag key can be used by other packages.
+//
+// It returns false if the value has no unit, so the next [ValueWriter] is used.
+//
+// It should be created with [NewUnitWriter].
+type UnitWriter struct {
+	ValueWriter
+	fields syncutil.Map[reflect.Type, []unitField]
+	// Types contains the units by type.
+	// Default: nil.
+	Types map[reflect.Type]Unit
+	// Tag is the key of the struct tag containing the unit of a field.
+	// If it is empty, the struct tags are ignored.
+	// The units of the fields are cached by struct type, so it should not be modified after the first use.
+	// Default: "unit".
+	Tag string
+	// FieldNames contains the units by field name pattern.
+	// The first matching pattern is used.
+	// The units of the fields are cached by struct type, so it should not be modified after the first use.
+	// Default: nil.
+	FieldNames []UnitFieldName
+	// Time is used to format the unix timestamps, with its Format and Location.
+	// If the Location is nil, UTC is used.
+	// Default: the [TimeTimeWriter] given to [NewUnitWriter].
+	Time *TimeTimeWriter
+}
+
+// NewUnitWriter creates a new [UnitWriter] with default values.
+//
+// The [ValueWriter] is used to write the number.
+// The [TimeTimeWriter] is used to format the unix timestamps.
+// If it is nil, a new [TimeTimeWriter] is created.
+func NewUnitWriter(vw ValueWriter, tw *TimeTimeWriter) *UnitWriter {
+	if tw == nil {
+		tw = NewTimeTimeWriter()
+	}
+	return &UnitWriter{
+		ValueWriter: vw,
+		Types:       nil,
+		Tag:         "unit",
+		FieldNames:  nil,
+		Time:        tw,
+	}
+}
+
+// WriteValue implements [ValueWriter].
+func (vw *UnitWriter) WriteValue(st *State, v reflect.Value) bool {
+	var i int64
+	switch v.Kind() { //nolint:exhaustive // Only handles integers.
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i = v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := v.Uint()
+		i = int64(u)
+		if i < 0 {
+			return false // Overflow.
+		}
+	default:
+		return false
+	}
+	unit := vw.getUnit(st, v.Type())
+	if unit == UnitNone {
+		return false
+	}
+	if !vw.ValueWriter.WriteValue(st, v) {
+		return false
+	}
+	if unitOverflows(i, unit) {
+		return true // Only the number is written.
+	}
+	st.Writer.AppendString(" (")
+	vw.writeHumanized(st, i, unit)
+	st.Writer.AppendByte(')')
+	return true
+}
+
+func (vw *UnitWriter) getUnit(st *State, typ reflect.Type) Unit {
+	if st.structField.pathLen != 0 && st.structField.pathLen == len(st.Path) {
+		f := vw.getFields(st.structField.typ)[st.structField.index]
+		if f.ok {
+			return f.unit
+		}
+	}
+	return vw.Types[typ]
+}
+
+// unitField is the unit of a struct field, from its tag or its name.
+type unitField struct {
+	unit Unit
+	ok   bool
+}
+
+// getFields returns the units of the fields of a struct type.
+//
+// They are computed once by type, because [path.Match] is slow.
+func (vw *UnitWriter) getFields(typ reflect.Type) []unitField {
+	fs, ok := vw.fields.Load(typ)
+	if ok {
+		return fs
+	}
+	fs = make([]unitField, typ.NumField())
+	for i := range fs {
+		fs[i] = vw.getField(typ.Field(i))
+	}
+	vw.fields.Store(typ, fs)
+	return fs
+}
+
+func (vw *UnitWriter) getField(field reflect.StructField) unitField {
+	if vw.Tag != "" {
+		if name, ok := field.Tag.Lookup(vw.Tag); ok {
+			if unit, ok := unitsByTagName[name]; ok {
+				return unitField{unit: unit, ok: true}
+			}
+		}
+	}
+	for _, fn := range vw.FieldNames {
+		if ok, _ := path.Match(fn.Pattern, field.Name); ok {
+			return unitField{unit: fn.Unit, ok: true}
+		}
+	}
+	return unitField{}
+}
+
+func (vw *UnitWriter) writeHumanized(st *State, i int64, unit Unit) {
+	switch unit {
+	case UnitBytes:
+		st.Writer = appendSize(st.Writer, i)
+	case UnitNanoseconds, UnitMicroseconds, UnitMilliseconds, UnitSeconds:
+		st.Writer.AppendString(time.Duration(i * unitDurations[unit]).String())
+	case UnitUnixSeconds, UnitUnixMilliseconds, UnitUnixMicroseconds, UnitUnixNanoseconds:
+		vw.writeTime(st, i, unit)
+	default:
+		st.Writer.AppendString("<unknown unit>")
+	}
+}
+
+var unitDurations = map[Unit]int64{
+	UnitNanoseconds:  int64(time.Nanosecond),
+	UnitMicroseconds: int64(time.Microsecond),
+	UnitMilliseconds: int64(time.Millisecond),
+	UnitSeconds:      int64(time.Second),
+}
+
+// unitOverflows returns true if the value converted to a [time.Duration] overflows.
+func unitOverflows(i int64, unit Unit) bool {
+	d, ok := unitDurations[unit]
+	return ok && (i > math.MaxInt64/d || i < math.MinInt64/d)
+}
+
+func (vw *UnitWriter) writeTime(st *State, i int64, unit Unit) {
+	var tm time.Time
+	switch unit { //nolint:exhaustive // Only handles timestamps.
+	case UnitUnixSeconds:
+		tm = time.Unix(i, 0)
+	case UnitUnixMilliseconds:
+		tm = time.UnixMilli(i)
+	case UnitUnixMicroseconds:
+		tm = time.UnixMicro(i)
+	case UnitUnixNanoseconds:
+		tm = time.Unix(0, i)
+	}
+	format := time.RFC3339Nano
+	loc := time.UTC
+	if vw.Time != nil {
+		format = vw.Time.Format
+		if vw.Time.Location != nil {
+			loc = vw.Time.Location
+		}
+	}
+	st.Writer = tm.In(loc).AppendFormat(st.Writer, format)
+}
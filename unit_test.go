@@ -0,0 +1,167 @@
+package pretty_test
+
+import (
+	"math"
+	"reflect"
+	"time"
+
+	. "github.com/pierrre/pretty"
+	"github.com/pierrre/pretty/internal/prettytest"
+)
+
+func init() {
+	prettytest.AddCasesPrefix("Unit", []*prettytest.Case{
+		{
+			Name:  "Type",
+			Value: testUnitBytes(1 << 30),
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Unit.Types = map[reflect.Type]Unit{
+					reflect.TypeFor[testUnitBytes](): UnitBytes,
+				}
+			},
+		},
+		{
+			Name: "Tag",
+			Value: testUnitStruct{
+				Size:      1 << 30,
+				Timeout:   1500,
+				Delay:     1500,
+				CreatedAt: 1700000000,
+				UpdatedAt: 1700000000123,
+				Elapsed:   90,
+				Unknown:   42,
+				Other:     42,
+			},
+		},
+		{
+			Name:  "TagDisabled",
+			Value: testUnitStruct{Size: 1 << 30},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Unit.Tag = ""
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "FieldNames",
+			Value: testUnitFieldNamesStruct{
+				BufferBytes: 2048,
+				TimeoutMS:   250,
+				Count:       3,
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Unit.FieldNames = []UnitFieldName{
+					{Pattern: "*Bytes", Unit: UnitBytes},
+					{Pattern: "*MS", Unit: UnitMilliseconds},
+				}
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "TagPriority",
+			Value: testUnitStruct{
+				Size: 1 << 30,
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Unit.FieldNames = []UnitFieldName{
+					{Pattern: "*", Unit: UnitSeconds},
+				}
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "Nested",
+			Value: testUnitNestedStruct{
+				Sizes: []int64{1024},
+				Inner: testUnitFieldNamesStruct{
+					BufferBytes: 1024,
+				},
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "TimeLocation",
+			Value: testUnitStruct{
+				CreatedAt: 1700000000,
+			},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Time.Time.Format = time.DateTime
+				vw.Time.Time.Location = time.FixedZone("Test", 2*60*60)
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "UnixUnits",
+			Value: testUnitUnixStruct{
+				Micro: 1700000000123456,
+				Nano:  1700000000123456789,
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name: "Overflow",
+			Value: testUnitOverflowStruct{
+				Seconds: math.MaxInt64 / 2,
+				Millis:  math.MinInt64 / 2,
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "IntBase",
+			Value: testUnitStruct{Timeout: 1500},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Kind.Int.Base = 16
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Disabled",
+			Value: testUnitStruct{Size: 1 << 30},
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.Unit = nil
+			},
+			IgnoreBenchmark: true,
+		},
+		{
+			Name:  "Not",
+			Value: "test",
+			ConfigureWriter: func(vw *CommonWriter) {
+				vw.ValueWriters = ValueWriters{vw.Unit}
+			},
+			IgnoreBenchmark: true,
+		},
+	})
+}
+
+type testUnitBytes uint64
+
+type testUnitStruct struct {
+	Size      uint64 `unit:"bytes"`
+	Timeout   int    `unit:"ms"`
+	Delay     int32  `unit:"us"`
+	CreatedAt int64  `unit:"unix"`
+	UpdatedAt int64  `unit:"unix_ms"`
+	Elapsed   uint16 `unit:"s"`
+	Unknown   int    `unit:"invalid"`
+	Other     int
+}
+
+type testUnitFieldNamesStruct struct {
+	BufferBytes int
+	TimeoutMS   int
+	Count       int
+}
+
+type testUnitNestedStruct struct {
+	Sizes []int64 `unit:"bytes"`
+	Inner testUnitFieldNamesStruct
+}
+
+type testUnitOverflowStruct struct {
+	Seconds int64 `unit:"s"`
+	Millis  int64 `unit:"ms"`
+}
+
+type testUnitUnixStruct struct {
+	Micro int64 `unit:"unix_us"`
+	Nano  int64 `unit:"unix_ns"`
+}
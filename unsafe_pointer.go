@@ -22,7 +22,7 @@ func (vw *UnsafePointerWriter) WriteValue(st *State, v reflect.Value) bool {
 	if checkNil(st, v) {
 		return true
 	}
-	writeUintptr(st, uintptr(v.UnsafePointer()))
+	writeAddr(st, uintptr(v.UnsafePointer()))
 	return true
 }
 
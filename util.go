@@ -2,8 +2,12 @@ package pretty
 
 import (
 	"reflect"
+
+	"github.com/pierrre/go-libs/bytesutil"
 )
 
+var bytesWriterPool bytesutil.WriterPool
+
 // Vars returns a slice of variadic arguments.
 // It allows calling a [Printer] with the result of a function returning multiple values.
 func Vars(vs ...any) []any {
@@ -24,6 +28,14 @@ func writeTruncated(st *State) {
 	st.Writer.AppendString("<truncated>")
 }
 
+func writeBlockTruncated(st *State, first bool) {
+	st.WriteBlockItemStart(first)
+	writeTruncated(st)
+	if !st.Compact {
+		st.writeNewLine()
+	}
+}
+
 func callSupportCheckerPointer[P interface {
 	*T
 	SupportChecker